@@ -0,0 +1,74 @@
+package gopenstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AuthOptions represents options of a keystone v3 password authentication
+type AuthOptions struct {
+	AuthUrl           string // Keystone URL (eg https://auth.example.com/v3)
+	UserId            string
+	Username          string
+	Password          string
+	UserDomainName    string
+	ProjectId         string
+	ProjectName       string
+	ProjectDomainName string
+}
+
+// NewKeyring authenticates against keystone and returns a keyring
+func NewKeyring(options *AuthOptions) (*Keyring, error) {
+	user := map[string]interface{}{"password": options.Password}
+	if options.UserId != "" {
+		user["id"] = options.UserId
+	} else {
+		user["name"] = options.Username
+		user["domain"] = map[string]string{"name": options.UserDomainName}
+	}
+	auth := map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods":  []string{"password"},
+			"password": map[string]interface{}{"user": user},
+		},
+	}
+	if options.ProjectId != "" {
+		auth["scope"] = map[string]interface{}{"project": map[string]string{"id": options.ProjectId}}
+	} else if options.ProjectName != "" {
+		auth["scope"] = map[string]interface{}{"project": map[string]interface{}{
+			"name":   options.ProjectName,
+			"domain": map[string]string{"name": options.ProjectDomainName},
+		}}
+	}
+	body, err := json.Marshal(map[string]interface{}{"auth": auth})
+	if err != nil {
+		return nil, err
+	}
+
+	authUrl := strings.TrimSuffix(options.AuthUrl, "/")
+	if !strings.HasSuffix(authUrl, "/v3") {
+		authUrl += "/v3"
+	}
+	req, err := http.NewRequest("POST", authUrl+"/auth/tokens", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("User-Agent", "gopenstack (https://github.com/Toorop/gopenstack)")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		return nil, &HttpError{resp.StatusCode, resp.Status}
+	}
+	keyring := new(Keyring)
+	if err = json.NewDecoder(resp.Body).Decode(keyring); err != nil {
+		return nil, err
+	}
+	keyring.XAuthHeaderToken = resp.Header.Get("X-Subject-Token")
+	return keyring, nil
+}
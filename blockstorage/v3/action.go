@@ -0,0 +1,73 @@
+package blockstorageV3
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Retype migration policies
+const (
+	MigrationNever    = "never"
+	MigrationOnDemand = "on-demand"
+)
+
+// volumeAction runs action on volume id
+func (c *Cinder) volumeAction(id string, action map[string]interface{}, microversion string, expectedHttpCode []int) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "POST",
+		Ressource:    "volumes/" + url.PathEscape(id) + "/action",
+		Microversion: microversion,
+	}, action, nil, expectedHttpCode)
+	return err
+}
+
+// ExtendVolume extends volume id to newSize GB
+// (in-use volumes require microversion >= 3.42)
+func (c *Cinder) ExtendVolume(id string, newSize int) error {
+	return c.volumeAction(id, map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	}, "", []int{202})
+}
+
+// RetypeVolume changes the type of volume id to newType
+// migrationPolicy (MigrationNever or MigrationOnDemand) allows migration to another backend
+func (c *Cinder) RetypeVolume(id, newType, migrationPolicy string) error {
+	params := map[string]string{"new_type": newType}
+	if migrationPolicy != "" {
+		params["migration_policy"] = migrationPolicy
+	}
+	return c.volumeAction(id, map[string]interface{}{"os-retype": params}, "", []int{202})
+}
+
+// SetVolumeBootable sets the bootable flag of volume id
+func (c *Cinder) SetVolumeBootable(id string, bootable bool) error {
+	return c.volumeAction(id, map[string]interface{}{
+		"os-set_bootable": map[string]bool{"bootable": bootable},
+	}, "", []int{200})
+}
+
+// ForceDetachVolume forces the detachment of volume id (admin only)
+// attachmentId is the attachment to remove (all if empty)
+func (c *Cinder) ForceDetachVolume(id, attachmentId string) error {
+	params := map[string]interface{}{}
+	if attachmentId != "" {
+		params["attachment_id"] = attachmentId
+	}
+	return c.volumeAction(id, map[string]interface{}{"os-force_detach": params}, "", []int{202})
+}
+
+// ResetVolumeStatus resets the status of volume id (admin only)
+func (c *Cinder) ResetVolumeStatus(id, status string) error {
+	return c.volumeAction(id, map[string]interface{}{
+		"os-reset_status": map[string]string{"status": status},
+	}, "", []int{202})
+}
+
+// RevertVolumeToSnapshot reverts volume id to its latest snapshot snapshotId
+// (microversion >= 3.40, requested if the client one is lower)
+func (c *Cinder) RevertVolumeToSnapshot(id, snapshotId string) error {
+	return c.volumeAction(id, map[string]interface{}{
+		"revert": map[string]string{"snapshot_id": snapshotId},
+	}, c.client.RequiredMicroversion("3.40"), []int{202})
+}
@@ -0,0 +1,135 @@
+package blockstorageV3
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Backup represents a cinder volume backup
+type Backup struct {
+	Id                  string                `json:"id"`
+	Name                string                `json:"name"`
+	Description         string                `json:"description"`
+	VolumeId            string                `json:"volume_id"`
+	SnapshotId          string                `json:"snapshot_id"`
+	Status              string                `json:"status"`
+	FailReason          string                `json:"fail_reason"`
+	Size                int                   `json:"size"` // GB
+	ObjectCount         int                   `json:"object_count"`
+	Container           string                `json:"container"`
+	AvailabilityZone    string                `json:"availability_zone"`
+	IsIncremental       bool                  `json:"is_incremental"`
+	HasDependentBackups bool                  `json:"has_dependent_backups"`
+	Metadata            map[string]string     `json:"metadata"` // microversion >= 3.43
+	DataTimestamp       gopenstack.DateTimeOs `json:"data_timestamp"`
+	CreatedAt           gopenstack.DateTimeOs `json:"created_at"`
+	UpdatedAt           gopenstack.DateTimeOs `json:"updated_at"`
+}
+
+// CreateBackupOptions represents options of a new backup
+type CreateBackupOptions struct {
+	VolumeId    string            `json:"volume_id"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Container   string            `json:"container,omitempty"`
+	SnapshotId  string            `json:"snapshot_id,omitempty"`
+	Incremental bool              `json:"incremental,omitempty"` // based on the latest backup of the volume
+	Force       bool              `json:"force,omitempty"`       // backup an in-use volume
+	Metadata    map[string]string `json:"metadata,omitempty"`    // microversion >= 3.43
+}
+
+// BackupRestore is the result of a backup restoration
+type BackupRestore struct {
+	BackupId   string `json:"backup_id"`
+	VolumeId   string `json:"volume_id"`
+	VolumeName string `json:"volume_name"`
+}
+
+// CreateBackup creates a backup, the returned backup is in creating status
+func (c *Cinder) CreateBackup(options *CreateBackupOptions) (*Backup, error) {
+	var r struct {
+		Backup Backup `json:"backup"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "backups",
+	}, map[string]interface{}{"backup": options}, &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Backup, nil
+}
+
+// GetBackup returns backup id
+func (c *Cinder) GetBackup(id string) (*Backup, error) {
+	var r struct {
+		Backup Backup `json:"backup"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "backups/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Backup, nil
+}
+
+// ListBackups returns backups (with details) matching options, following pages
+func (c *Cinder) ListBackups(options *ListOptions) (backups []Backup, err error) {
+	o := ListOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		var r struct {
+			Backups []Backup          `json:"backups"`
+			Links   []gopenstack.Link `json:"backups_links"`
+		}
+		_, err = c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "backups/detail" + o.query(),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return
+		}
+		backups = append(backups, r.Backups...)
+		if o.Marker = gopenstack.NextMarker(r.Links); o.Marker == "" || len(r.Backups) == 0 {
+			return
+		}
+	}
+}
+
+// RestoreBackup restores backup id to volume volumeId, or to a new volume
+// named name if volumeId is empty
+func (c *Cinder) RestoreBackup(id, volumeId, name string) (*BackupRestore, error) {
+	restore := map[string]string{}
+	if volumeId != "" {
+		restore["volume_id"] = volumeId
+	}
+	if name != "" {
+		restore["name"] = name
+	}
+	var r struct {
+		Restore BackupRestore `json:"restore"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "backups/" + url.PathEscape(id) + "/restore",
+	}, map[string]interface{}{"restore": restore}, &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Restore, nil
+}
+
+// DeleteBackup deletes backup id (asynchronous)
+// Backups with dependent incremental backups can not be deleted
+func (c *Cinder) DeleteBackup(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "backups/" + url.PathEscape(id),
+	}, nil, nil, []int{202})
+	return err
+}
@@ -0,0 +1,66 @@
+package blockstorageV3
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Cinder is a high-level representation of the openstack block storage service (cinder v3)
+// The client must be created with the "volumev3" (or "block-storage") catalog type.
+// Microversions are requested with the client (SetMicroversion or NegotiateMicroversion).
+type Cinder struct {
+	client *gopenstack.Client
+}
+
+// NewCinder returns a Cinder
+func NewCinder(client *gopenstack.Client) *Cinder {
+	return &Cinder{client: client}
+}
+
+// ListOptions represents filters and pagination of listings
+type ListOptions struct {
+	// Filters on resources attributes (eg name, status, volume_id...)
+	Filters    map[string]string
+	Metadata   map[string]string // Resources having these metadata
+	AllTenants bool              // Admin only
+	Sort       string            // eg "name:asc,created_at:desc"
+	Limit      int               // Page size
+	Marker     string            // Id of the last resource of the previous page
+}
+
+// query returns the query string corresponding to options
+func (o *ListOptions) query() string {
+	v := url.Values{}
+	if o == nil {
+		return ""
+	}
+	for k, value := range o.Filters {
+		v.Set(k, value)
+	}
+	if len(o.Metadata) != 0 {
+		// cinder expects a python dict literal
+		keys := make([]string, 0, len(o.Metadata))
+		for k := range o.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		metadata := "{"
+		for _, k := range keys {
+			if len(metadata) > 1 {
+				metadata += ","
+			}
+			metadata += strconv.Quote(k) + ":" + strconv.Quote(o.Metadata[k])
+		}
+		v.Set("metadata", metadata+"}")
+	}
+	if o.AllTenants {
+		v.Set("all_tenants", "1")
+	}
+	if o.Sort != "" {
+		v.Set("sort", o.Sort)
+	}
+	return gopenstack.PageQuery(v, o.Limit, o.Marker)
+}
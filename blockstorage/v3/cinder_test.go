@@ -0,0 +1,88 @@
+package blockstorageV3
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/Toorop/gopenstack/gopenstacktest"
+)
+
+func TestListOptionsQuery(t *testing.T) {
+	o := &ListOptions{
+		Filters:    map[string]string{"status": "available"},
+		Metadata:   map[string]string{"env": "prod", "team": `a "b"`},
+		AllTenants: true,
+		Limit:      2,
+	}
+	q, err := url.ParseQuery(o.query()[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := url.Values{
+		"status":      {"available"},
+		"metadata":    {`{"env":"prod","team":"a \"b\""}`},
+		"all_tenants": {"1"},
+		"limit":       {"2"},
+	}
+	if !reflect.DeepEqual(q, expected) {
+		t.Errorf("query %v, expected %v", q, expected)
+	}
+	if q := (*ListOptions)(nil).query(); q != "" {
+		t.Errorf("nil options query: %q", q)
+	}
+}
+
+func TestVolumeUnmarshalJSON(t *testing.T) {
+	for data, bootable := range map[string]bool{
+		`{"id": "v1", "bootable": "true"}`:  true,
+		`{"id": "v1", "bootable": "false"}`: false,
+		`{"id": "v1", "bootable": true}`:    true,
+		`{"id": "v1"}`:                      false,
+	} {
+		var v Volume
+		if err := json.Unmarshal([]byte(data), &v); err != nil || v.Id != "v1" || v.Bootable != bootable {
+			t.Errorf("%s: %+v, %v", data, v, err)
+		}
+	}
+}
+
+func TestQuotaSetUnmarshalJSON(t *testing.T) {
+	var q QuotaSet
+	err := json.Unmarshal([]byte(`{"id": "p1", "volumes": 10, "gigabytes": 1000, "volumes_ssd": 5, "gigabytes_ssd": -1}`), &q)
+	if err != nil || q.Id != "p1" || q.Volumes != 10 || q.Gigabytes != 1000 {
+		t.Fatalf("quota set: %+v, %v", q, err)
+	}
+	if expected := map[string]int{"volumes_ssd": 5, "gigabytes_ssd": -1}; !reflect.DeepEqual(q.Types, expected) {
+		t.Errorf("per type quotas %v, expected %v", q.Types, expected)
+	}
+}
+
+func TestListVolumesPages(t *testing.T) {
+	var markers []string
+	c := NewCinder(gopenstacktest.NewClient(t, "volumev3", "/v3/p1", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/p1/volumes/detail" {
+			http.NotFound(w, r)
+			return
+		}
+		marker := r.URL.Query().Get("marker")
+		markers = append(markers, marker)
+		switch marker {
+		case "":
+			w.Write([]byte(`{"volumes": [{"id": "v1"}, {"id": "v2"}], "volumes_links": [{"rel": "next", "href": "http://cinder/v3/p1/volumes/detail?limit=2&marker=v2"}]}`))
+		case "v2":
+			w.Write([]byte(`{"volumes": [{"id": "v3"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	volumes, err := c.ListVolumes(&ListOptions{Limit: 2})
+	if err != nil || len(volumes) != 3 || volumes[2].Id != "v3" {
+		t.Errorf("volumes: %+v, %v", volumes, err)
+	}
+	if !reflect.DeepEqual(markers, []string{"", "v2"}) {
+		t.Errorf("markers: %q", markers)
+	}
+}
@@ -0,0 +1,117 @@
+package blockstorageV3
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A QuotaSet represents block storage quotas of a project (-1 is unlimited)
+// Per volume type quotas (eg "volumes_ssd", "gigabytes_ssd") are in Types
+type QuotaSet struct {
+	Id                 string         `json:"id"`
+	Volumes            int            `json:"volumes"`
+	Snapshots          int            `json:"snapshots"`
+	Gigabytes          int            `json:"gigabytes"`
+	Backups            int            `json:"backups"`
+	BackupGigabytes    int            `json:"backup_gigabytes"`
+	PerVolumeGigabytes int            `json:"per_volume_gigabytes"`
+	Groups             int            `json:"groups"`
+	Types              map[string]int `json:"-"`
+}
+
+// QuotaUsage is the limit and usage of a quota
+type QuotaUsage struct {
+	Limit     int `json:"limit"`
+	InUse     int `json:"in_use"`
+	Reserved  int `json:"reserved"`
+	Allocated int `json:"allocated"`
+}
+
+// quotaNames are the names of QuotaSet fields
+var quotaNames = map[string]bool{
+	"id": true, "volumes": true, "snapshots": true, "gigabytes": true, "backups": true,
+	"backup_gigabytes": true, "per_volume_gigabytes": true, "groups": true,
+}
+
+// UnmarshalJSON sets per volume type quotas in Types
+func (q *QuotaSet) UnmarshalJSON(data []byte) error {
+	type quotaSet QuotaSet
+	if err := json.Unmarshal(data, (*quotaSet)(q)); err != nil {
+		return err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	q.Types = make(map[string]int)
+	for k, v := range all {
+		if n, ok := v.(float64); ok && !quotaNames[k] {
+			q.Types[k] = int(n)
+		}
+	}
+	return nil
+}
+
+// GetQuotaSet returns quotas of project projectId
+func (c *Cinder) GetQuotaSet(projectId string) (*QuotaSet, error) {
+	return c.getQuotaSet("os-quota-sets/" + url.PathEscape(projectId))
+}
+
+// GetDefaultQuotaSet returns default quotas of project projectId
+func (c *Cinder) GetDefaultQuotaSet(projectId string) (*QuotaSet, error) {
+	return c.getQuotaSet("os-quota-sets/" + url.PathEscape(projectId) + "/defaults")
+}
+
+// getQuotaSet returns the quota set at ressource
+func (c *Cinder) getQuotaSet(ressource string) (*QuotaSet, error) {
+	var r struct {
+		QuotaSet QuotaSet `json:"quota_set"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: ressource,
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.QuotaSet, nil
+}
+
+// GetQuotaUsage returns quotas of project projectId with their usage, by quota name
+func (c *Cinder) GetQuotaUsage(projectId string) (map[string]QuotaUsage, error) {
+	var r struct {
+		QuotaSet map[string]json.RawMessage `json:"quota_set"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "os-quota-sets/" + url.PathEscape(projectId) + "?usage=true",
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]QuotaUsage)
+	for k, raw := range r.QuotaSet {
+		var u QuotaUsage
+		if json.Unmarshal(raw, &u) == nil && k != "id" {
+			usage[k] = u
+		}
+	}
+	return usage, nil
+}
+
+// UpdateQuotaSet updates quotas (by name, eg "volumes" or "gigabytes_ssd") of project projectId (admin only)
+func (c *Cinder) UpdateQuotaSet(projectId string, quotas map[string]int) (*QuotaSet, error) {
+	var r struct {
+		QuotaSet QuotaSet `json:"quota_set"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: "os-quota-sets/" + url.PathEscape(projectId),
+	}, map[string]interface{}{"quota_set": quotas}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.QuotaSet, nil
+}
@@ -0,0 +1,119 @@
+package blockstorageV3
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Snapshot represents a cinder volume snapshot
+type Snapshot struct {
+	Id              string                `json:"id"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	VolumeId        string                `json:"volume_id"`
+	Status          string                `json:"status"`
+	Size            int                   `json:"size"` // GB
+	Metadata        map[string]string     `json:"metadata"`
+	Progress        string                `json:"os-extended-snapshot-attributes:progress"`
+	ProjectId       string                `json:"os-extended-snapshot-attributes:project_id"`
+	GroupSnapshotId string                `json:"group_snapshot_id"` // microversion >= 3.14
+	UserId          string                `json:"user_id"`           // microversion >= 3.41
+	CreatedAt       gopenstack.DateTimeOs `json:"created_at"`
+	UpdatedAt       gopenstack.DateTimeOs `json:"updated_at"`
+}
+
+// CreateSnapshotOptions represents options of a new snapshot
+type CreateSnapshotOptions struct {
+	VolumeId    string            `json:"volume_id"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Force       bool              `json:"force,omitempty"` // snapshot an in-use volume
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateSnapshot creates a snapshot, the returned snapshot is in creating status
+func (c *Cinder) CreateSnapshot(options *CreateSnapshotOptions) (*Snapshot, error) {
+	var r struct {
+		Snapshot Snapshot `json:"snapshot"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "snapshots",
+	}, map[string]interface{}{"snapshot": options}, &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Snapshot, nil
+}
+
+// GetSnapshot returns snapshot id
+func (c *Cinder) GetSnapshot(id string) (*Snapshot, error) {
+	var r struct {
+		Snapshot Snapshot `json:"snapshot"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "snapshots/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Snapshot, nil
+}
+
+// ListSnapshots returns snapshots (with details) matching options, following pages
+func (c *Cinder) ListSnapshots(options *ListOptions) (snapshots []Snapshot, err error) {
+	o := ListOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		var r struct {
+			Snapshots []Snapshot        `json:"snapshots"`
+			Links     []gopenstack.Link `json:"snapshots_links"`
+		}
+		_, err = c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "snapshots/detail" + o.query(),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return
+		}
+		snapshots = append(snapshots, r.Snapshots...)
+		if o.Marker = gopenstack.NextMarker(r.Links); o.Marker == "" || len(r.Snapshots) == 0 {
+			return
+		}
+	}
+}
+
+// UpdateSnapshot updates name and description of snapshot id (empty ones are not updated)
+func (c *Cinder) UpdateSnapshot(id, name, description string) (*Snapshot, error) {
+	snapshot := map[string]string{}
+	if name != "" {
+		snapshot["name"] = name
+	}
+	if description != "" {
+		snapshot["description"] = description
+	}
+	var r struct {
+		Snapshot Snapshot `json:"snapshot"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: "snapshots/" + url.PathEscape(id),
+	}, map[string]interface{}{"snapshot": snapshot}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Snapshot, nil
+}
+
+// DeleteSnapshot deletes snapshot id (asynchronous)
+func (c *Cinder) DeleteSnapshot(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "snapshots/" + url.PathEscape(id),
+	}, nil, nil, []int{202})
+	return err
+}
@@ -0,0 +1,205 @@
+package blockstorageV3
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Volume status
+const (
+	StatusAvailable   = "available"
+	StatusCreating    = "creating"
+	StatusInUse       = "in-use"
+	StatusAttaching   = "attaching"
+	StatusDetaching   = "detaching"
+	StatusDeleting    = "deleting"
+	StatusExtending   = "extending"
+	StatusRetyping    = "retyping"
+	StatusError       = "error"
+	StatusReverting   = "reverting"
+	StatusBackingUp   = "backing-up"
+	StatusRestoring   = "restoring-backup"
+	StatusDownloading = "downloading"
+)
+
+// errorStatuses are statuses failing waits
+var errorStatuses = []string{"error", "error_deleting", "error_extending", "error_restoring", "error_managing", "error_backing-up"}
+
+// A Volume represents a cinder volume
+type Volume struct {
+	Id                string                `json:"id"`
+	Name              string                `json:"name"`
+	Description       string                `json:"description"`
+	Status            string                `json:"status"`
+	Size              int                   `json:"size"` // GB
+	VolumeType        string                `json:"volume_type"`
+	AvailabilityZone  string                `json:"availability_zone"`
+	Bootable          bool                  `json:"-"`
+	Encrypted         bool                  `json:"encrypted"`
+	Multiattach       bool                  `json:"multiattach"`
+	SnapshotId        string                `json:"snapshot_id"`
+	SourceVolid       string                `json:"source_volid"`
+	BackupId          string                `json:"backup_id"` // microversion >= 3.47
+	Metadata          map[string]string     `json:"metadata"`
+	ImageMetadata     map[string]string     `json:"volume_image_metadata"` // volumes created from an image
+	Attachments       []Attachment          `json:"attachments"`
+	MigrationStatus   string                `json:"migration_status"`
+	ReplicationStatus string                `json:"replication_status"`
+	GroupId           string                `json:"group_id"` // microversion >= 3.13
+	UserId            string                `json:"user_id"`
+	ProjectId         string                `json:"os-vol-tenant-attr:tenant_id"`
+	Host              string                `json:"os-vol-host-attr:host"` // admin only
+	SharedTargets     bool                  `json:"shared_targets"`        // microversion >= 3.48
+	ClusterName       string                `json:"cluster_name"`          // microversion >= 3.61
+	ConsumesQuota     bool                  `json:"consumes_quota"`        // microversion >= 3.65
+	CreatedAt         gopenstack.DateTimeOs `json:"created_at"`
+	UpdatedAt         gopenstack.DateTimeOs `json:"updated_at"`
+	Links             []gopenstack.Link     `json:"links"`
+}
+
+// UnmarshalJSON handles bootable returned as a string
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	type volume Volume
+	r := struct {
+		*volume
+		Bootable interface{} `json:"bootable"`
+	}{volume: (*volume)(v)}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	switch b := r.Bootable.(type) {
+	case bool:
+		v.Bootable = b
+	case string:
+		v.Bootable = b == "true"
+	}
+	return nil
+}
+
+// An Attachment is an attachment of a volume to a server
+type Attachment struct {
+	Id           string                `json:"id"`
+	AttachmentId string                `json:"attachment_id"`
+	VolumeId     string                `json:"volume_id"`
+	ServerId     string                `json:"server_id"`
+	HostName     string                `json:"host_name"`
+	Device       string                `json:"device"`
+	AttachedAt   gopenstack.DateTimeOs `json:"attached_at"`
+}
+
+// CreateVolumeOptions represents options of a new volume
+// The volume is empty or created from an image (ImageRef), a snapshot
+// (SnapshotId), another volume (SourceVolid) or a backup (BackupId)
+type CreateVolumeOptions struct {
+	Size             int               `json:"size,omitempty"` // GB, optional for snapshot, volume or backup sources
+	Name             string            `json:"name,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	VolumeType       string            `json:"volume_type,omitempty"`
+	AvailabilityZone string            `json:"availability_zone,omitempty"`
+	ImageRef         string            `json:"imageRef,omitempty"`
+	SnapshotId       string            `json:"snapshot_id,omitempty"`
+	SourceVolid      string            `json:"source_volid,omitempty"`
+	BackupId         string            `json:"backup_id,omitempty"` // microversion >= 3.47
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Multiattach      bool              `json:"multiattach,omitempty"`
+	GroupId          string            `json:"group_id,omitempty"` // microversion >= 3.13
+
+	SchedulerHints map[string]interface{} `json:"-"`
+}
+
+// CreateVolume creates a volume, the returned volume is in creating status
+func (c *Cinder) CreateVolume(options *CreateVolumeOptions) (*Volume, error) {
+	body := map[string]interface{}{"volume": options}
+	if len(options.SchedulerHints) != 0 {
+		body["OS-SCH-HNT:scheduler_hints"] = options.SchedulerHints
+	}
+	var r struct {
+		Volume Volume `json:"volume"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "volumes",
+	}, body, &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Volume, nil
+}
+
+// GetVolume returns volume id
+func (c *Cinder) GetVolume(id string) (*Volume, error) {
+	var r struct {
+		Volume Volume `json:"volume"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "volumes/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Volume, nil
+}
+
+// ListVolumes returns volumes (with details) matching options, following pages
+func (c *Cinder) ListVolumes(options *ListOptions) (volumes []Volume, err error) {
+	o := ListOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		var r struct {
+			Volumes []Volume          `json:"volumes"`
+			Links   []gopenstack.Link `json:"volumes_links"`
+		}
+		_, err = c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "volumes/detail" + o.query(),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return
+		}
+		volumes = append(volumes, r.Volumes...)
+		if o.Marker = gopenstack.NextMarker(r.Links); o.Marker == "" || len(r.Volumes) == 0 {
+			return
+		}
+	}
+}
+
+// UpdateVolumeOptions represents updatable volume attributes, empty ones are not updated
+type UpdateVolumeOptions struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"` // replaces all metadata
+}
+
+// UpdateVolume updates volume id
+func (c *Cinder) UpdateVolume(id string, options *UpdateVolumeOptions) (*Volume, error) {
+	var r struct {
+		Volume Volume `json:"volume"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: "volumes/" + url.PathEscape(id),
+	}, map[string]interface{}{"volume": options}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Volume, nil
+}
+
+// DeleteVolume deletes volume id (asynchronous)
+// If cascade is set its snapshots are deleted too
+func (c *Cinder) DeleteVolume(id string, cascade bool) error {
+	ressource := "volumes/" + url.PathEscape(id)
+	if cascade {
+		ressource += "?cascade=true"
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: ressource,
+	}, nil, nil, []int{202})
+	return err
+}
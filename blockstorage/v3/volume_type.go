@@ -0,0 +1,81 @@
+package blockstorageV3
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A VolumeType represents a cinder volume type
+type VolumeType struct {
+	Id          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	IsPublic    bool              `json:"os-volume-type-access:is_public"`
+	ExtraSpecs  map[string]string `json:"extra_specs"` // admin only
+	QosSpecsId  string            `json:"qos_specs_id"`
+}
+
+// CreateVolumeTypeOptions represents options of a new volume type
+type CreateVolumeTypeOptions struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	IsPublic    *bool             `json:"os-volume-type-access:is_public,omitempty"`
+	ExtraSpecs  map[string]string `json:"extra_specs,omitempty"`
+}
+
+// ListVolumeTypes returns volume types
+func (c *Cinder) ListVolumeTypes() ([]VolumeType, error) {
+	var r struct {
+		VolumeTypes []VolumeType `json:"volume_types"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "types",
+	}, nil, &r, []int{200})
+	return r.VolumeTypes, err
+}
+
+// GetVolumeType returns volume type id
+func (c *Cinder) GetVolumeType(id string) (*VolumeType, error) {
+	var r struct {
+		VolumeType VolumeType `json:"volume_type"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "types/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.VolumeType, nil
+}
+
+// GetDefaultVolumeType returns the default volume type
+func (c *Cinder) GetDefaultVolumeType() (*VolumeType, error) {
+	return c.GetVolumeType("default")
+}
+
+// CreateVolumeType creates a volume type (admin only)
+func (c *Cinder) CreateVolumeType(options *CreateVolumeTypeOptions) (*VolumeType, error) {
+	var r struct {
+		VolumeType VolumeType `json:"volume_type"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "types",
+	}, map[string]interface{}{"volume_type": options}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.VolumeType, nil
+}
+
+// DeleteVolumeType deletes volume type id (admin only)
+func (c *Cinder) DeleteVolumeType(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "types/" + url.PathEscape(id),
+	}, nil, nil, []int{202})
+	return err
+}
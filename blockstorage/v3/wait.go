@@ -0,0 +1,66 @@
+package blockstorageV3
+
+import (
+	"context"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// WaitForVolumeStatus waits until volume id reaches status (eg StatusAvailable)
+// It fails if the volume goes in an error status
+func (c *Cinder) WaitForVolumeStatus(ctx context.Context, id, status string) (*Volume, error) {
+	var volume *Volume
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		v, err := c.GetVolume(id)
+		if err != nil {
+			return "", err
+		}
+		volume = v
+		return v.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{status}, Failure: errorStatuses})
+	return volume, err
+}
+
+// WaitForVolumeDeleted waits until volume id is deleted
+func (c *Cinder) WaitForVolumeDeleted(ctx context.Context, id string) error {
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		v, err := c.GetVolume(id)
+		if err != nil {
+			return "", err
+		}
+		return v.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{"deleted"}, Failure: errorStatuses, NotFoundIsTarget: true})
+	return err
+}
+
+// WaitForSnapshotStatus waits until snapshot id reaches status (eg StatusAvailable)
+func (c *Cinder) WaitForSnapshotStatus(ctx context.Context, id, status string) (*Snapshot, error) {
+	var snapshot *Snapshot
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		s, err := c.GetSnapshot(id)
+		if err != nil {
+			return "", err
+		}
+		snapshot = s
+		return s.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{status}, Failure: errorStatuses})
+	return snapshot, err
+}
+
+// WaitForBackupStatus waits until backup id reaches status (eg StatusAvailable)
+// It fails if the backup goes in error status (the fail reason is the reason)
+func (c *Cinder) WaitForBackupStatus(ctx context.Context, id, status string) (*Backup, error) {
+	var backup *Backup
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		b, err := c.GetBackup(id)
+		if err != nil {
+			return "", err
+		}
+		backup = b
+		return b.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{status}, Failure: errorStatuses})
+	if se, ok := err.(*gopenstack.StatusError); ok {
+		se.Reason = backup.FailReason
+	}
+	return backup, err
+}
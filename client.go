@@ -1,24 +1,34 @@
 package gopenstack
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync"
 )
 
 type Client struct {
-	client     *http.Client
-	xAuthToken string
-	endpoint   string
+	client       *http.Client
+	xAuthToken   string
+	endpoint     string
+	serviceType  string      // catalog type
+	mu           sync.Mutex  // protects microversion and versions
+	microversion string      // default microversion of calls
+	versions     *APIVersion // discovered version document
 }
 
 // newRaClient returns a new apiClient
 func NewClient(keyring *Keyring, region, iType string) (c *Client, err error) {
 	c = new(Client)
-	c.client = &http.Client{}
+	// Objects stored with a Content-Encoding must not be decoded by the transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = true
+	c.client = &http.Client{Transport: transport}
 	c.xAuthToken = keyring.XAuthHeaderToken
+	c.serviceType = iType
 	c.endpoint, err = keyring.GetEndpointUrl(iType, region)
 	return
 }
@@ -30,6 +40,8 @@ type cResponse struct {
 	Headers    http.Header
 	Body       []byte
 	BodyReader io.ReadCloser
+	// Microversion is the microversion used by the service to answer (if any)
+	Microversion string
 }
 
 // handleCommon return error on unexpected HTTP code
@@ -42,7 +54,7 @@ func (r *cResponse) HandleErr(err error, expectedHttpCode []int) error {
 			return nil
 		}
 	}
-	return errors.New(fmt.Sprintf("%d - %s", r.StatusCode, r.Status))
+	return &HttpError{r.StatusCode, r.Status}
 }
 
 type CallOptions struct {
@@ -51,15 +63,26 @@ type CallOptions struct {
 	Headers            map[string]string
 	Payload            io.Reader
 	ReturnBodyAsReader bool
+	Endpoint           string // Endpoint overrides client endpoint if set
+	Microversion       string // Microversion overrides client microversion if set
+}
+
+// GetEndpoint returns the endpoint of the client
+func (c *Client) GetEndpoint() string {
+	return c.endpoint
 }
 
 func (c *Client) Call(options *CallOptions) (response *cResponse, err error) {
 	var req *http.Request
 	response = new(cResponse)
-	if options.Ressource[0] == 47 {
+	if len(options.Ressource) > 0 && options.Ressource[0] == 47 {
 		options.Ressource = options.Ressource[1:]
 	}
-	query := fmt.Sprintf("%s/%s", c.endpoint, options.Ressource)
+	endpoint := c.endpoint
+	if options.Endpoint != "" {
+		endpoint = options.Endpoint
+	}
+	query := fmt.Sprintf("%s/%s", endpoint, options.Ressource)
 
 	req, err = http.NewRequest(options.Method, query, options.Payload)
 	if err != nil {
@@ -70,6 +93,15 @@ func (c *Client) Call(options *CallOptions) (response *cResponse, err error) {
 	req.Header.Add("X-Auth-Token", c.xAuthToken)
 	req.Header.Add("User-Agent", "gopenstack (https://github.com/Toorop/gopenstack)")
 
+	// Microversion
+	microversion := c.GetMicroversion()
+	if options.Microversion != "" {
+		microversion = options.Microversion
+	}
+	if microversion != "" {
+		c.setMicroversionHeaders(req.Header, microversion)
+	}
+
 	// Extra headers
 	for k, v := range options.Headers {
 		req.Header.Add(k, v)
@@ -89,5 +121,31 @@ func (c *Client) Call(options *CallOptions) (response *cResponse, err error) {
 	response.StatusCode = resp.StatusCode
 	response.Status = resp.Status
 	response.Headers = resp.Header
+	response.Microversion = c.responseMicroversion(resp.Header)
+	return
+}
+
+// CallJSON does a call with in (if not nil) as JSON payload and decodes
+// the JSON response body in out (if not nil)
+func (c *Client) CallJSON(options *CallOptions, in, out interface{}, expectedHttpCode []int) (response *cResponse, err error) {
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		options.Payload = bytes.NewReader(payload)
+		headers := map[string]string{"Content-Type": "application/json"}
+		for k, v := range options.Headers {
+			headers[k] = v
+		}
+		options.Headers = headers
+	}
+	response, err = c.Call(options)
+	if err = response.HandleErr(err, expectedHttpCode); err != nil {
+		return
+	}
+	if out != nil && len(response.Body) != 0 {
+		err = json.Unmarshal(response.Body, out)
+	}
 	return
 }
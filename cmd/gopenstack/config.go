@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Toorop/gopenstack"
+	"gopkg.in/yaml.v2"
+)
+
+// errAuth wraps authentication errors
+var errAuth = errors.New("authentication failed")
+
+// authError is an authentication error (it matches errAuth)
+type authError struct {
+	err error
+}
+
+func (e *authError) Error() string        { return "authentication failed: " + e.err.Error() }
+func (e *authError) Is(target error) bool { return target == errAuth }
+func (e *authError) Unwrap() error        { return e.err }
+
+// cloudsFile represents a clouds.yaml file
+type cloudsFile struct {
+	Clouds map[string]cloudConfig `yaml:"clouds"`
+}
+
+// cloudConfig represents a cloud entry of clouds.yaml
+type cloudConfig struct {
+	Auth struct {
+		AuthUrl           string `yaml:"auth_url"`
+		Username          string `yaml:"username"`
+		UserId            string `yaml:"user_id"`
+		Password          string `yaml:"password"`
+		UserDomainName    string `yaml:"user_domain_name"`
+		ProjectId         string `yaml:"project_id"`
+		ProjectName       string `yaml:"project_name"`
+		ProjectDomainName string `yaml:"project_domain_name"`
+	} `yaml:"auth"`
+	RegionName string `yaml:"region_name"`
+}
+
+// cloudsFilePaths returns the clouds.yaml search path
+func cloudsFilePaths() []string {
+	if p := os.Getenv("OS_CLIENT_CONFIG_FILE"); p != "" {
+		return []string{p}
+	}
+	paths := []string{"clouds.yaml"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "openstack", "clouds.yaml"))
+	}
+	return append(paths, "/etc/openstack/clouds.yaml")
+}
+
+// loadCloud returns the cloud named name from the first clouds.yaml found
+func loadCloud(name string) (*cloudConfig, error) {
+	for _, p := range cloudsFilePaths() {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		var f cloudsFile
+		if err = yaml.Unmarshal(data, &f); err != nil {
+			return nil, errors.New(p + ": " + err.Error())
+		}
+		if c, ok := f.Clouds[name]; ok {
+			return &c, nil
+		}
+	}
+	return nil, errors.New("cloud " + name + " not found in clouds.yaml")
+}
+
+// authOptions returns auth options and region from clouds.yaml (if a cloud is set)
+// or from OS_* environment variables
+func (e *env) authOptions() (*gopenstack.AuthOptions, string, error) {
+	if e.cloud != "" {
+		c, err := loadCloud(e.cloud)
+		if err != nil {
+			return nil, "", err
+		}
+		region := e.region
+		if region == "" {
+			region = c.RegionName
+		}
+		a := c.Auth
+		return &gopenstack.AuthOptions{
+			AuthUrl:           a.AuthUrl,
+			UserId:            a.UserId,
+			Username:          a.Username,
+			Password:          a.Password,
+			UserDomainName:    firstNonEmpty(a.UserDomainName, "Default"),
+			ProjectId:         a.ProjectId,
+			ProjectName:       a.ProjectName,
+			ProjectDomainName: firstNonEmpty(a.ProjectDomainName, "Default"),
+		}, region, nil
+	}
+	if os.Getenv("OS_AUTH_URL") == "" {
+		return nil, "", errors.New("no credentials: set -os-cloud (or OS_CLOUD) or OS_AUTH_URL")
+	}
+	return &gopenstack.AuthOptions{
+		AuthUrl:           os.Getenv("OS_AUTH_URL"),
+		UserId:            os.Getenv("OS_USER_ID"),
+		Username:          os.Getenv("OS_USERNAME"),
+		Password:          os.Getenv("OS_PASSWORD"),
+		UserDomainName:    firstNonEmpty(os.Getenv("OS_USER_DOMAIN_NAME"), "Default"),
+		ProjectId:         firstNonEmpty(os.Getenv("OS_PROJECT_ID"), os.Getenv("OS_TENANT_ID")),
+		ProjectName:       firstNonEmpty(os.Getenv("OS_PROJECT_NAME"), os.Getenv("OS_TENANT_NAME")),
+		ProjectDomainName: firstNonEmpty(os.Getenv("OS_PROJECT_DOMAIN_NAME"), "Default"),
+	}, e.region, nil
+}
+
+// keyring authenticates and returns the keyring and the region
+func (e *env) keyring() (*gopenstack.Keyring, string, error) {
+	options, region, err := e.authOptions()
+	if err != nil {
+		return nil, "", &authError{err}
+	}
+	keyring, err := gopenstack.NewKeyring(options)
+	if err != nil {
+		return nil, "", &authError{err}
+	}
+	return keyring, region, nil
+}
+
+// firstNonEmpty returns the first non empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
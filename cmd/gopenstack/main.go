@@ -0,0 +1,116 @@
+// Command gopenstack is a command line client for openstack services
+//
+// Usage:
+//
+//	gopenstack [-os-cloud name] [-os-region-name region] [-o table|json] swift <command> [args]
+//
+// Authentication is read from clouds.yaml (-os-cloud or OS_CLOUD) or from
+// the OS_* environment variables.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Exit codes
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitAuth     = 3
+	exitNotFound = 4
+)
+
+// errUsage is returned on bad command line usage
+var errUsage = errors.New("usage")
+
+// env represents the execution environment of a command
+type env struct {
+	cloud  string
+	region string
+	output string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr}
+	flags := flag.NewFlagSet("gopenstack", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&e.cloud, "os-cloud", os.Getenv("OS_CLOUD"), "cloud name in clouds.yaml")
+	flags.StringVar(&e.region, "os-region-name", os.Getenv("OS_REGION_NAME"), "region name")
+	flags.StringVar(&e.output, "o", "table", "output format: table or json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gopenstack [flags] swift <command> [args]")
+		fmt.Fprintln(stderr, "\nCommands:\n  "+strings.Join(swiftCommandNames(), ", "))
+		fmt.Fprintln(stderr, "\nFlags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if e.output != "table" && e.output != "json" {
+		fmt.Fprintln(stderr, "invalid output format: "+e.output)
+		return exitUsage
+	}
+	args = flags.Args()
+	if len(args) < 1 || args[0] != "swift" {
+		flags.Usage()
+		return exitUsage
+	}
+	return e.exitCode(runSwift(e, args[1:]))
+}
+
+// exitCode prints err and returns the corresponding exit code
+func (e *env) exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if err == errUsage {
+		return exitUsage
+	}
+	fmt.Fprintln(e.stderr, "Error: "+err.Error())
+	var httpErr *gopenstack.HttpError
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return exitNotFound
+	case errors.As(err, &httpErr) && httpErr.StatusCode == 404:
+		return exitNotFound
+	case errors.As(err, &httpErr) && (httpErr.StatusCode == 401 || httpErr.StatusCode == 403):
+		return exitAuth
+	case errors.Is(err, errAuth):
+		return exitAuth
+	}
+	return exitError
+}
+
+// print writes v as JSON or rows as a table depending on the output format
+func (e *env) print(v interface{}, header []string, rows [][]string) error {
+	if e.output == "json" {
+		enc := json.NewEncoder(e.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	w := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"crypto/md5"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+)
+
+// swiftCommand is a swift subcommand
+type swiftCommand struct {
+	usage            string
+	run              func(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error
+	flags            func(flags *flag.FlagSet)
+	minArgs, maxArgs int // Accepted number of arguments (no limit if maxArgs < 0)
+}
+
+// swiftCommands are the swift subcommands
+var swiftCommands = map[string]swiftCommand{
+	"ls":      {usage: "ls [path]", run: swiftLs, minArgs: 0, maxArgs: 1},
+	"cp":      {usage: "cp [-preserve-symlinks] [-verify] src dest", run: swiftCp, flags: copyFlags, minArgs: 2, maxArgs: 2},
+	"mv":      {usage: "mv [-preserve-symlinks] src dest", run: swiftMv, flags: moveFlags, minArgs: 2, maxArgs: 2},
+	"rm":      {usage: "rm path...", run: swiftRm, minArgs: 1, maxArgs: -1},
+	"stat":    {usage: "stat path", run: swiftStat, minArgs: 1, maxArgs: 1},
+	"sync":    {usage: "sync [-delete] src dest", run: swiftSync, flags: func(f *flag.FlagSet) { f.Bool("delete", false, "delete extraneous files from dest") }, minArgs: 2, maxArgs: 2},
+	"mkdir":   {usage: "mkdir container...", run: swiftMkdir, minArgs: 1, maxArgs: -1},
+	"tempurl": {usage: "tempurl [-method GET] [-ttl 1h] [-key key] path", run: swiftTempURL, flags: tempURLFlags, minArgs: 1, maxArgs: 1},
+}
+
+// swiftCommandNames returns sorted swift subcommands names
+func swiftCommandNames() []string {
+	names := []string{}
+	for name := range swiftCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runSwift runs a swift subcommand
+func runSwift(e *env, args []string) error {
+	if len(args) < 1 {
+		fmt.Fprintln(e.stderr, "Usage: gopenstack swift <command> [args]\n\nCommands:\n  "+strings.Join(swiftCommandNames(), ", "))
+		return errUsage
+	}
+	cmd, ok := swiftCommands[args[0]]
+	if !ok {
+		fmt.Fprintln(e.stderr, "unknown swift command: "+args[0])
+		return errUsage
+	}
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: gopenstack swift "+cmd.usage)
+		flags.PrintDefaults()
+	}
+	if cmd.flags != nil {
+		cmd.flags(flags)
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+	// validate before authenticating
+	if n := flags.NArg(); n < cmd.minArgs || (cmd.maxArgs >= 0 && n > cmd.maxArgs) {
+		flags.Usage()
+		return errUsage
+	}
+
+	keyring, region, err := e.keyring()
+	if err != nil {
+		return err
+	}
+	client, err := objectStorageV1.NewClient(keyring, region)
+	if err != nil {
+		return err
+	}
+	err = cmd.run(e, objectStorageV1.NewSwift(client), client, flags, flags.Args())
+	if err == errUsage {
+		flags.Usage()
+	}
+	return err
+}
+
+func copyFlags(f *flag.FlagSet) {
+	moveFlags(f)
+	f.Bool("verify", false, "check integrity of transferred data")
+}
+
+// moveFlags are the flags of mv (moves are always verified)
+func moveFlags(f *flag.FlagSet) {
+	f.Bool("preserve-symlinks", false, "preserve symlinks instead of following them")
+}
+
+func tempURLFlags(f *flag.FlagSet) {
+	f.String("method", "GET", "HTTP method allowed by the URL")
+	f.Duration("ttl", time.Hour, "validity of the URL")
+	f.String("key", "", "Temp-URL-Key (default: account key)")
+}
+
+// boolFlag returns the value of the bool flag name
+func boolFlag(flags *flag.FlagSet, name string) bool {
+	v, _ := strconv.ParseBool(flags.Lookup(name).Value.String())
+	return v
+}
+
+// swiftLs lists containers or children of a path
+func swiftLs(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	if len(args) == 0 || args[0] == "/" {
+		containers, err := s.ListContainers()
+		if err != nil {
+			return err
+		}
+		rows := [][]string{}
+		for _, c := range containers {
+			rows = append(rows, []string{c.Name, strconv.FormatUint(c.Count, 10), strconv.FormatUint(c.Bytes, 10)})
+		}
+		return e.print(containers, []string{"NAME", "OBJECTS", "BYTES"}, rows)
+	}
+	children, err := objectStorageV1.NewOsPath(client, args[0]).ListChildren()
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, c := range children {
+		modified := ""
+		if !c.LastModified.IsZero() {
+			modified = c.LastModified.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{c.Name, c.ContentType, strconv.FormatUint(c.Bytes, 10), modified})
+	}
+	return e.print(children, []string{"NAME", "TYPE", "BYTES", "LAST MODIFIED"}, rows)
+}
+
+// swiftCp copies local <-> remote paths
+func swiftCp(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	return s.CopyWithOptions(args[0], args[1], &objectStorageV1.CopyOptions{
+		PreserveSymlinks: boolFlag(flags, "preserve-symlinks"),
+		Verify:           boolFlag(flags, "verify"),
+	})
+}
+
+// swiftMv copies then removes the source
+// The copy is always verified and the source is kept if anything failed
+func swiftMv(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	err := s.CopyWithOptions(args[0], args[1], &objectStorageV1.CopyOptions{
+		PreserveSymlinks: boolFlag(flags, "preserve-symlinks"),
+		Verify:           true,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(args[0]); err == nil {
+		return os.RemoveAll(args[0])
+	}
+	return s.DeletePath(args[0])
+}
+
+// swiftRm removes paths (recursively)
+func swiftRm(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	for _, p := range args {
+		if err := s.DeletePath(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swiftMkdir creates containers
+func swiftMkdir(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	for _, c := range args {
+		if err := s.AddContainer(strings.Trim(c, "/")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swiftStat displays container or object informations
+func swiftStat(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	p, err := objectStorageV1.ParsePath(args[0])
+	if err != nil {
+		return err
+	}
+	switch p.Kind() {
+	case objectStorageV1.KindContainer:
+		c, err := s.HeadContainer(p.Container)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{
+			{"Container", c.Name},
+			{"Objects", strconv.FormatUint(c.Count, 10)},
+			{"Bytes", strconv.FormatUint(c.Bytes, 10)},
+			{"Storage Policy", c.StoragePolicy},
+		}
+		return e.print(c, nil, append(rows, metadataRows(c.Metadata)...))
+	case objectStorageV1.KindObject:
+		o, err := s.HeadObject(p.String())
+		if err != nil {
+			return err
+		}
+		rows := [][]string{
+			{"Container", p.Container},
+			{"Object", o.Name},
+			{"Content Type", o.ContentType},
+			{"Bytes", strconv.FormatUint(o.Bytes, 10)},
+			{"ETag", o.Hash},
+			{"Last Modified", o.LastModified.Format(time.RFC3339)},
+		}
+		if o.SymlinkPath != "" {
+			rows = append(rows, []string{"Symlink Target", o.SymlinkPath})
+		}
+		if !o.DeleteAt.IsZero() {
+			rows = append(rows, []string{"Delete At", o.DeleteAt.Format(time.RFC3339)})
+		}
+		return e.print(o, nil, append(rows, metadataRows(o.Metadata)...))
+	}
+	return errUsage
+}
+
+// metadataRows returns metadata as sorted table rows
+func metadataRows(metadata map[string]string) (rows [][]string) {
+	for k, v := range metadata {
+		rows = append(rows, []string{"Meta " + k, v})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+	return
+}
+
+// swiftTempURL prints a temporary URL
+func swiftTempURL(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	ttl, err := time.ParseDuration(flags.Lookup("ttl").Value.String())
+	if err != nil {
+		return err
+	}
+	u, err := s.TempURL(flags.Lookup("method").Value.String(), args[0], ttl, flags.Lookup("key").Value.String())
+	if err != nil {
+		return err
+	}
+	return e.print(map[string]string{"url": u}, nil, [][]string{{u}})
+}
+
+// swiftSync synchronizes src to dest (local -> remote or remote -> local)
+// Only modified files are transferred
+func swiftSync(e *env, s *objectStorageV1.Swift, client *gopenstack.Client, flags *flag.FlagSet, args []string) error {
+	src, dest := args[0], args[1]
+	if _, err := os.Stat(src); err == nil {
+		return syncUp(s, client, src, dest, boolFlag(flags, "delete"))
+	}
+	if _, err := os.Stat(dest); err != nil {
+		return gopenstack.ErrPathNotFound(dest)
+	}
+	return syncDown(s, client, src, dest, boolFlag(flags, "delete"))
+}
+
+// syncUp uploads local src under dest (as Put does) and deletes remote extraneous objects
+func syncUp(s *objectStorageV1.Swift, client *gopenstack.Client, src, dest string, del bool) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	if err = s.Put(src, dest); err != nil || !del {
+		return err
+	}
+	remote := objectStorageV1.NewOsPath(client, strings.TrimSuffix(dest, "/")+"/"+filepath.Base(src))
+	rp, err := remote.GetPath()
+	if err != nil {
+		return err
+	}
+	objects, err := remote.GetChildrenObjects()
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		local := filepath.Join(src, filepath.FromSlash(o.Name[len(rp.Prefix()):]))
+		if _, err := os.Lstat(local); os.IsNotExist(err) {
+			if err = s.DeleteObject(rp.Container + "/" + o.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncDown downloads remote src under local dest (as DownloadPath does) and deletes local extraneous files
+func syncDown(s *objectStorageV1.Swift, client *gopenstack.Client, src, dest string, del bool) error {
+	remote := objectStorageV1.NewOsPath(client, src)
+	rp, err := remote.GetPath()
+	if err != nil {
+		return err
+	}
+	if rp.IsRoot() {
+		return gopenstack.ErrNoContainerSpecified
+	}
+	objects, err := remote.GetChildrenObjects()
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(dest, rp.Base())
+	keep := make(map[string]bool)
+	for _, o := range objects {
+		local := filepath.Join(root, filepath.FromSlash(o.Name[len(rp.Prefix()):]))
+		keep[local] = true
+		if sum, err := md5File(local); err == nil && sum == o.Hash {
+			continue
+		}
+		if err = s.DownloadObject(rp.Container+"/"+o.Name, local); err != nil {
+			return err
+		}
+	}
+	if !del {
+		return nil
+	}
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || keep[path] {
+			return err
+		}
+		return os.Remove(path)
+	})
+}
+
+// md5File returns the hex md5 of file path
+func md5File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+	"github.com/Toorop/gopenstack/objectStorage/v1/swifttest"
+)
+
+// newTestServer starts a swifttest server with objects in container c
+// and points the OS_* environment variables to it
+func newTestServer(t *testing.T, objects map[string]string) (*swifttest.Server, *objectStorageV1.Swift) {
+	srv := swifttest.NewServer()
+	t.Cleanup(srv.Close)
+	t.Setenv("OS_CLOUD", "")
+	t.Setenv("OS_AUTH_URL", srv.AuthURL())
+	t.Setenv("OS_USERNAME", "swifttest")
+	t.Setenv("OS_PASSWORD", "swifttest")
+	t.Setenv("OS_PROJECT_NAME", "swifttest")
+	t.Setenv("OS_REGION_NAME", srv.Region)
+	s, err := srv.Swift()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.AddContainer("c"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range objects {
+		if err = s.PutObject("/c/"+name, strings.NewReader(content), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return srv, s
+}
+
+// runCommand runs the command line args and returns the exit code and stderr
+func runCommand(args ...string) (int, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stderr.String()
+}
+
+func TestMvRemoteToLocal(t *testing.T) {
+	_, s := newTestServer(t, map[string]string{"dir/a.txt": "aaa", "dir/b.txt": "bbb"})
+	dest := t.TempDir()
+	if code, stderr := runCommand("swift", "mv", "/c/dir", dest); code != exitOK {
+		t.Fatalf("mv exit code %d: %s", code, stderr)
+	}
+	for name, content := range map[string]string{"a.txt": "aaa", "b.txt": "bbb"} {
+		data, err := ioutil.ReadFile(filepath.Join(dest, "dir", name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s: got %q, %v", name, data, err)
+		}
+	}
+	if _, err := s.HeadObject("/c/dir/a.txt"); err == nil {
+		t.Error("source was not removed")
+	}
+}
+
+func TestMvKeepsSourceOnDownloadFailure(t *testing.T) {
+	for name, fault := range map[string]swifttest.Fault{
+		"error":     {Method: "GET", Path: "/c/dir/b.txt", StatusCode: 500},
+		"truncated": {Method: "GET", Path: "/c/dir/b.txt", TruncateBody: 1},
+		"wrongEtag": {Method: "GET", Path: "/c/dir/b.txt", WrongEtag: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv, s := newTestServer(t, map[string]string{"dir/a.txt": "aaa", "dir/b.txt": "bbb"})
+			srv.AddFault(fault)
+			code, _ := runCommand("swift", "mv", "/c/dir", t.TempDir())
+			if code != exitError {
+				t.Errorf("exit code %d, expected %d", code, exitError)
+			}
+			for _, o := range []string{"/c/dir/a.txt", "/c/dir/b.txt"} {
+				if _, err := s.HeadObject(o); err != nil {
+					t.Errorf("%s: source removed: %v", o, err)
+				}
+			}
+			for _, r := range srv.Requests() {
+				if r.Method == "DELETE" || strings.Contains(r.Query, "bulk-delete") {
+					t.Errorf("unexpected %s %s?%s", r.Method, r.Path, r.Query)
+				}
+			}
+		})
+	}
+}
+
+func TestMvKeepsSourceOnUploadFailure(t *testing.T) {
+	srv, _ := newTestServer(t, nil)
+	src := filepath.Join(t.TempDir(), "dir")
+	if err := os.MkdirAll(src, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "a.txt"), []byte("aaa"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	srv.AddFault(swifttest.Fault{Method: "PUT", Path: "/c/dir/a.txt", StatusCode: 503})
+	if code, _ := runCommand("swift", "mv", src, "/c"); code != exitError {
+		t.Errorf("exit code %d, expected %d", code, exitError)
+	}
+	if _, err := os.Stat(filepath.Join(src, "a.txt")); err != nil {
+		t.Errorf("source removed: %v", err)
+	}
+}
+
+func TestCpEmptyContainer(t *testing.T) {
+	newTestServer(t, nil)
+	dest := t.TempDir()
+	done := make(chan int)
+	go func() {
+		code, _ := runCommand("swift", "cp", "/c", dest)
+		done <- code
+	}()
+	select {
+	case code := <-done:
+		if code != exitOK {
+			t.Errorf("exit code %d", code)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("cp of an empty container does not return")
+	}
+}
+
+func TestCpMissingSource(t *testing.T) {
+	newTestServer(t, nil)
+	if code, _ := runCommand("swift", "cp", "/c/missing", t.TempDir()); code != exitNotFound {
+		t.Errorf("exit code %d, expected %d", code, exitNotFound)
+	}
+}
+
+func TestUsageBeforeAuth(t *testing.T) {
+	srv, _ := newTestServer(t, nil)
+	for _, args := range [][]string{
+		{"swift", "cp", "/c"},
+		{"swift", "stat"},
+		{"swift", "ls", "/c", "/d"},
+		{"swift", "mv", "-verify", "/c/a", "/c/b"},
+		{"swift", "unknown"},
+	} {
+		srv.ResetRequests()
+		if code, stderr := runCommand(args...); code != exitUsage || stderr == "" {
+			t.Errorf("%v: exit code %d, %s", args, code, stderr)
+		}
+		if requests := srv.Requests(); len(requests) != 0 {
+			t.Errorf("%v: %d requests sent before validating arguments", args, len(requests))
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package computeV2
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Reboot types
+const (
+	RebootSoft = "SOFT"
+	RebootHard = "HARD"
+)
+
+// serverAction runs action on server id, the response is decoded in out (if not nil)
+func (c *Compute) serverAction(id string, action map[string]interface{}, out interface{}, expectedHttpCode []int) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "servers/" + url.PathEscape(id) + "/action",
+	}, action, out, expectedHttpCode)
+	return err
+}
+
+// RebootServer reboots server id, rebootType is RebootSoft or RebootHard
+func (c *Compute) RebootServer(id, rebootType string) error {
+	return c.serverAction(id, map[string]interface{}{
+		"reboot": map[string]string{"type": rebootType},
+	}, nil, []int{202})
+}
+
+// ResizeServer resizes server id to flavor flavorRef
+// The resize must then be confirmed or reverted (server is in VERIFY_RESIZE state)
+func (c *Compute) ResizeServer(id, flavorRef string) error {
+	return c.serverAction(id, map[string]interface{}{
+		"resize": map[string]string{"flavorRef": flavorRef},
+	}, nil, []int{202})
+}
+
+// ConfirmResizeServer confirms a pending resize of server id
+func (c *Compute) ConfirmResizeServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"confirmResize": nil}, nil, []int{204})
+}
+
+// RevertResizeServer reverts a pending resize of server id
+func (c *Compute) RevertResizeServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"revertResize": nil}, nil, []int{202})
+}
+
+// RebuildServerOptions represents options of a server rebuild
+type RebuildServerOptions struct {
+	ImageRef          string            `json:"imageRef"`
+	Name              string            `json:"name,omitempty"`
+	AdminPass         string            `json:"adminPass,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	KeyName           string            `json:"key_name,omitempty"`  // microversion >= 2.54
+	UserData          []byte            `json:"user_data,omitempty"` // microversion >= 2.57
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	PreserveEphemeral bool              `json:"preserve_ephemeral,omitempty"`
+}
+
+// RebuildServer rebuilds server id with options.ImageRef
+func (c *Compute) RebuildServer(id string, options *RebuildServerOptions) (*Server, error) {
+	var r struct {
+		Server Server `json:"server"`
+	}
+	err := c.serverAction(id, map[string]interface{}{"rebuild": options}, &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Server, nil
+}
+
+// StartServer starts (powers on) server id
+func (c *Compute) StartServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"os-start": nil}, nil, []int{202})
+}
+
+// StopServer stops (powers off) server id
+func (c *Compute) StopServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"os-stop": nil}, nil, []int{202})
+}
+
+// PauseServer pauses server id
+func (c *Compute) PauseServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"pause": nil}, nil, []int{202})
+}
+
+// UnpauseServer unpauses server id
+func (c *Compute) UnpauseServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"unpause": nil}, nil, []int{202})
+}
+
+// SuspendServer suspends server id
+func (c *Compute) SuspendServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"suspend": nil}, nil, []int{202})
+}
+
+// ResumeServer resumes suspended server id
+func (c *Compute) ResumeServer(id string) error {
+	return c.serverAction(id, map[string]interface{}{"resume": nil}, nil, []int{202})
+}
+
+// CreateServerImage creates an image (snapshot) of server id and returns its id
+func (c *Compute) CreateServerImage(id, name string, metadata map[string]string) (string, error) {
+	params := map[string]interface{}{"name": name}
+	if len(metadata) != 0 {
+		params["metadata"] = metadata
+	}
+	resp, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "servers/" + url.PathEscape(id) + "/action",
+	}, map[string]interface{}{"createImage": params}, nil, []int{202})
+	if err != nil {
+		return "", err
+	}
+	// from microversion 2.45 the image id is in the body, before in Location header
+	var r struct {
+		ImageId string `json:"image_id"`
+	}
+	if len(resp.Body) != 0 {
+		if err = json.Unmarshal(resp.Body, &r); err == nil && r.ImageId != "" {
+			return r.ImageId, nil
+		}
+	}
+	location := resp.Headers.Get("Location")
+	if location == "" {
+		return "", gopenstack.ErrNoImageId
+	}
+	return path.Base(location), nil
+}
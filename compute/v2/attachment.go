@@ -0,0 +1,124 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// An Interface represents a network interface attached to a server
+type Interface struct {
+	PortId    string    `json:"port_id"`
+	NetId     string    `json:"net_id"`
+	MacAddr   string    `json:"mac_addr"`
+	PortState string    `json:"port_state"`
+	FixedIps  []FixedIp `json:"fixed_ips"`
+	Tag       string    `json:"tag"` // microversion >= 2.70
+}
+
+// FixedIp is a fixed IP of an interface
+type FixedIp struct {
+	SubnetId  string `json:"subnet_id,omitempty"`
+	IpAddress string `json:"ip_address"`
+}
+
+// AttachInterfaceOptions represents options of an interface attachment
+// Either PortId or NetId (optionally with FixedIps) must be set
+type AttachInterfaceOptions struct {
+	PortId   string    `json:"port_id,omitempty"`
+	NetId    string    `json:"net_id,omitempty"`
+	FixedIps []FixedIp `json:"fixed_ips,omitempty"`
+	Tag      string    `json:"tag,omitempty"` // microversion >= 2.49
+}
+
+// ListServerInterfaces returns network interfaces of server id
+func (c *Compute) ListServerInterfaces(id string) ([]Interface, error) {
+	var r struct {
+		Interfaces []Interface `json:"interfaceAttachments"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-interface",
+	}, nil, &r, []int{200})
+	return r.Interfaces, err
+}
+
+// AttachServerInterface attaches a network interface to server id
+func (c *Compute) AttachServerInterface(id string, options *AttachInterfaceOptions) (*Interface, error) {
+	var r struct {
+		Interface Interface `json:"interfaceAttachment"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-interface",
+	}, map[string]interface{}{"interfaceAttachment": options}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Interface, nil
+}
+
+// DetachServerInterface detaches interface of port portId from server id (asynchronous)
+func (c *Compute) DetachServerInterface(id, portId string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-interface/" + url.PathEscape(portId),
+	}, nil, nil, []int{202})
+	return err
+}
+
+// A VolumeAttachment represents a volume attached to a server
+type VolumeAttachment struct {
+	Id                  string `json:"id"`
+	ServerId            string `json:"serverId"`
+	VolumeId            string `json:"volumeId"`
+	Device              string `json:"device"`
+	Tag                 string `json:"tag"`                   // microversion >= 2.70
+	DeleteOnTermination bool   `json:"delete_on_termination"` // microversion >= 2.79
+	AttachmentId        string `json:"attachment_id"`         // microversion >= 2.89
+	BdmUuid             string `json:"bdm_uuid"`              // microversion >= 2.89
+}
+
+// AttachVolumeOptions represents options of a volume attachment
+type AttachVolumeOptions struct {
+	VolumeId            string `json:"volumeId"`
+	Device              string `json:"device,omitempty"`                // eg /dev/vdb, chosen by nova if empty
+	Tag                 string `json:"tag,omitempty"`                   // microversion >= 2.49
+	DeleteOnTermination bool   `json:"delete_on_termination,omitempty"` // microversion >= 2.79
+}
+
+// ListVolumeAttachments returns volumes attached to server id
+func (c *Compute) ListVolumeAttachments(id string) ([]VolumeAttachment, error) {
+	var r struct {
+		VolumeAttachments []VolumeAttachment `json:"volumeAttachments"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-volume_attachments",
+	}, nil, &r, []int{200})
+	return r.VolumeAttachments, err
+}
+
+// AttachVolume attaches a volume to server id
+func (c *Compute) AttachVolume(id string, options *AttachVolumeOptions) (*VolumeAttachment, error) {
+	var r struct {
+		VolumeAttachment VolumeAttachment `json:"volumeAttachment"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-volume_attachments",
+	}, map[string]interface{}{"volumeAttachment": options}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.VolumeAttachment, nil
+}
+
+// DetachVolume detaches volume volumeId from server id (asynchronous)
+func (c *Compute) DetachVolume(id, volumeId string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-volume_attachments/" + url.PathEscape(volumeId),
+	}, nil, nil, []int{202})
+	return err
+}
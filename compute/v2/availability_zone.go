@@ -0,0 +1,42 @@
+package computeV2
+
+import (
+	"github.com/Toorop/gopenstack"
+)
+
+// An AvailabilityZone represents a nova availability zone
+type AvailabilityZone struct {
+	Name  string `json:"zoneName"`
+	State struct {
+		Available bool `json:"available"`
+	} `json:"zoneState"`
+	// Hosts and their services (detailed listing only)
+	Hosts map[string]map[string]AvailabilityZoneService `json:"hosts"`
+}
+
+// AvailabilityZoneService is the state of a nova service of an availability zone host
+type AvailabilityZoneService struct {
+	Available bool                  `json:"available"`
+	Active    bool                  `json:"active"`
+	UpdatedAt gopenstack.DateTimeOs `json:"updated_at"`
+}
+
+// ListAvailabilityZones returns availability zones
+// If detail is set (admin only), hosts and services of zones are returned
+func (c *Compute) ListAvailabilityZones(detail bool) ([]AvailabilityZone, error) {
+	ressource := "os-availability-zone"
+	if detail {
+		ressource += "/detail"
+	}
+	var r struct {
+		Zones []AvailabilityZone `json:"availabilityZoneInfo"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: ressource,
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return r.Zones, nil
+}
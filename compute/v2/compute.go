@@ -0,0 +1,43 @@
+package computeV2
+
+import (
+	"github.com/Toorop/gopenstack"
+)
+
+// A Compute is a high-level representation of the openstack compute service (nova v2.1)
+// The client must be created with the "compute" catalog type
+type Compute struct {
+	client *gopenstack.Client
+}
+
+// NewCompute returns a Compute
+func NewCompute(client *gopenstack.Client) *Compute {
+	return &Compute{client: client}
+}
+
+// namedResource is a resource candidate of a name resolution
+type namedResource struct {
+	id   string
+	name string
+}
+
+// resolveId returns the id of the kind resource named (or identified by) nameOrId
+// It fails if no or more than one candidate matches nameOrId
+func resolveId(kind, nameOrId string, candidates []namedResource) (string, error) {
+	var ids []string
+	for _, r := range candidates {
+		if r.id == nameOrId {
+			return r.id, nil
+		}
+		if r.name == nameOrId {
+			ids = append(ids, r.id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", gopenstack.ErrResourceNotFound(kind, nameOrId)
+	case 1:
+		return ids[0], nil
+	}
+	return "", gopenstack.ErrAmbiguousName(kind, nameOrId, ids)
+}
@@ -0,0 +1,32 @@
+package computeV2
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+)
+
+func TestResolveId(t *testing.T) {
+	candidates := []namedResource{{"id1", "web"}, {"id2", "db"}, {"id3", "db"}}
+
+	if id, err := resolveId("server", "web", candidates); err != nil || id != "id1" {
+		t.Errorf("by name: %q, %v", id, err)
+	}
+	if id, err := resolveId("server", "id3", candidates); err != nil || id != "id3" {
+		t.Errorf("by id: %q, %v", id, err)
+	}
+
+	_, err := resolveId("server", "db", candidates)
+	var ambiguous *gopenstack.AmbiguousNameError
+	if !errors.As(err, &ambiguous) || ambiguous.Kind != "server" || !reflect.DeepEqual(ambiguous.Ids, []string{"id2", "id3"}) {
+		t.Errorf("ambiguous name: %v", err)
+	}
+
+	_, err = resolveId("server", "missing", candidates)
+	var notFound *gopenstack.ResourceNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("missing name: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Remote console protocols and types
+const (
+	ConsoleProtocolVnc    = "vnc"
+	ConsoleProtocolSpice  = "spice"
+	ConsoleProtocolSerial = "serial"
+	ConsoleProtocolRdp    = "rdp"
+	ConsoleProtocolMks    = "mks"
+
+	ConsoleTypeNoVnc      = "novnc"
+	ConsoleTypeXvpVnc     = "xvpvnc"
+	ConsoleTypeSpiceHtml5 = "spice-html5"
+	ConsoleTypeSerial     = "serial"
+	ConsoleTypeRdpHtml5   = "rdp-html5"
+	ConsoleTypeWebmks     = "webmks"
+)
+
+// A RemoteConsole represents a remote console of a server
+type RemoteConsole struct {
+	Protocol string `json:"protocol"`
+	Type     string `json:"type"`
+	Url      string `json:"url"`
+}
+
+// GetConsoleOutput returns the console log of server id
+// limited to its last lines (whole log if lines <= 0)
+func (c *Compute) GetConsoleOutput(id string, lines int) (string, error) {
+	params := map[string]interface{}{}
+	if lines > 0 {
+		params["length"] = lines
+	}
+	var r struct {
+		Output string `json:"output"`
+	}
+	err := c.serverAction(id, map[string]interface{}{"os-getConsoleOutput": params}, &r, []int{200})
+	return r.Output, err
+}
+
+// GetRemoteConsole returns a remote console URL of server id
+// (microversion >= 2.6, requested if the client one is lower)
+func (c *Compute) GetRemoteConsole(id, protocol, consoleType string) (*RemoteConsole, error) {
+	var r struct {
+		RemoteConsole RemoteConsole `json:"remote_console"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "POST",
+		Ressource:    "servers/" + url.PathEscape(id) + "/remote-consoles",
+		Microversion: c.client.RequiredMicroversion("2.6"),
+	}, map[string]interface{}{
+		"remote_console": RemoteConsole{Protocol: protocol, Type: consoleType},
+	}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.RemoteConsole, nil
+}
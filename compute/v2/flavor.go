@@ -0,0 +1,163 @@
+package computeV2
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Flavor represents a nova flavor
+type Flavor struct {
+	Id          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"` // microversion >= 2.55
+	Vcpus       int               `json:"vcpus"`
+	Ram         int               `json:"ram"`  // MB
+	Disk        int               `json:"disk"` // GB
+	Swap        int               `json:"-"`    // MB
+	Ephemeral   int               `json:"OS-FLV-EXT-DATA:ephemeral"`
+	RxtxFactor  float64           `json:"rxtx_factor"`
+	IsPublic    bool              `json:"os-flavor-access:is_public"`
+	Disabled    bool              `json:"OS-FLV-DISABLED:disabled"`
+	ExtraSpecs  map[string]string `json:"extra_specs"` // set by nova from microversion 2.61
+	Links       []gopenstack.Link `json:"links"`
+}
+
+// UnmarshalJSON handles swap returned as an empty string when 0 (before microversion 2.75)
+func (f *Flavor) UnmarshalJSON(data []byte) error {
+	type flavor Flavor
+	r := struct {
+		*flavor
+		Swap interface{} `json:"swap"`
+	}{flavor: (*flavor)(f)}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	if swap, ok := r.Swap.(float64); ok {
+		f.Swap = int(swap)
+	}
+	return nil
+}
+
+// ListFlavorsOptions represents filters and pagination of flavors listing
+type ListFlavorsOptions struct {
+	MinDisk  int
+	MinRam   int
+	IsPublic string // "true", "false" or "none" (all, admin only)
+	Limit    int    // Page size
+	Marker   string // Id of the last flavor of the previous page
+
+	// WithExtraSpecs fetches extra specs of flavors if not returned
+	// by the listing (one call per flavor before microversion 2.61)
+	WithExtraSpecs bool
+}
+
+// query returns the query string corresponding to options
+func (o *ListFlavorsOptions) query() string {
+	v := url.Values{}
+	if o == nil {
+		return ""
+	}
+	if o.MinDisk > 0 {
+		v.Set("minDisk", strconv.Itoa(o.MinDisk))
+	}
+	if o.MinRam > 0 {
+		v.Set("minRam", strconv.Itoa(o.MinRam))
+	}
+	if o.IsPublic != "" {
+		v.Set("is_public", o.IsPublic)
+	}
+	return gopenstack.PageQuery(v, o.Limit, o.Marker)
+}
+
+// ListFlavors returns all flavors (with details) matching options, following pages
+func (c *Compute) ListFlavors(options *ListFlavorsOptions) (flavors []Flavor, err error) {
+	o := ListFlavorsOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		var r struct {
+			Flavors []Flavor          `json:"flavors"`
+			Links   []gopenstack.Link `json:"flavors_links"`
+		}
+		_, err = c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "flavors/detail" + o.query(),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return
+		}
+		flavors = append(flavors, r.Flavors...)
+		next := gopenstack.NextMarker(r.Links)
+		if next == "" || len(r.Flavors) == 0 {
+			break
+		}
+		o.Marker = next
+	}
+	if o.WithExtraSpecs {
+		for i := range flavors {
+			if flavors[i].ExtraSpecs != nil {
+				continue
+			}
+			if flavors[i].ExtraSpecs, err = c.GetFlavorExtraSpecs(flavors[i].Id); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetFlavor returns flavor id with its extra specs
+func (c *Compute) GetFlavor(id string) (*Flavor, error) {
+	var r struct {
+		Flavor Flavor `json:"flavor"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "flavors/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	if r.Flavor.ExtraSpecs == nil {
+		if r.Flavor.ExtraSpecs, err = c.GetFlavorExtraSpecs(id); err != nil {
+			return nil, err
+		}
+	}
+	return &r.Flavor, nil
+}
+
+// GetFlavorExtraSpecs returns extra specs of flavor id
+func (c *Compute) GetFlavorExtraSpecs(id string) (map[string]string, error) {
+	var r struct {
+		ExtraSpecs map[string]string `json:"extra_specs"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "flavors/" + url.PathEscape(id) + "/os-extra_specs",
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	if r.ExtraSpecs == nil {
+		r.ExtraSpecs = map[string]string{}
+	}
+	return r.ExtraSpecs, nil
+}
+
+// FlavorIdFromName returns the id of flavor nameOrId
+// (nameOrId is returned if it is the id of a flavor)
+func (c *Compute) FlavorIdFromName(nameOrId string) (string, error) {
+	flavors, err := c.ListFlavors(&ListFlavorsOptions{IsPublic: "none"})
+	if err != nil {
+		return "", err
+	}
+	candidates := make([]namedResource, len(flavors))
+	for i, f := range flavors {
+		candidates[i] = namedResource{f.Id, f.Name}
+	}
+	return resolveId("flavor", nameOrId, candidates)
+}
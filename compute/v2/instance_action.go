@@ -0,0 +1,57 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// An InstanceAction represents an action done on a server (create, reboot...)
+type InstanceAction struct {
+	Action       string                `json:"action"`
+	InstanceUuid string                `json:"instance_uuid"`
+	Message      string                `json:"message"`
+	ProjectId    string                `json:"project_id"`
+	UserId       string                `json:"user_id"`
+	RequestId    string                `json:"request_id"`
+	StartTime    gopenstack.DateTimeOs `json:"start_time"`
+	UpdatedAt    gopenstack.DateTimeOs `json:"updated_at"` // microversion >= 2.58
+	Events       []InstanceActionEvent `json:"events"`     // GetServerAction only
+}
+
+// An InstanceActionEvent is a step of an instance action
+type InstanceActionEvent struct {
+	Event      string                `json:"event"`
+	Result     string                `json:"result"`
+	Traceback  string                `json:"traceback"` // admin only
+	Host       string                `json:"host"`      // microversion >= 2.62
+	StartTime  gopenstack.DateTimeOs `json:"start_time"`
+	FinishTime gopenstack.DateTimeOs `json:"finish_time"`
+}
+
+// ListServerActions returns the action log of server id
+func (c *Compute) ListServerActions(id string) ([]InstanceAction, error) {
+	var r struct {
+		InstanceActions []InstanceAction `json:"instanceActions"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-instance-actions",
+	}, nil, &r, []int{200})
+	return r.InstanceActions, err
+}
+
+// GetServerAction returns action requestId of server id with its events
+func (c *Compute) GetServerAction(id, requestId string) (*InstanceAction, error) {
+	var r struct {
+		InstanceAction InstanceAction `json:"instanceAction"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id) + "/os-instance-actions/" + url.PathEscape(requestId),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.InstanceAction, nil
+}
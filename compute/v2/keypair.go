@@ -0,0 +1,103 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Keypair types
+const (
+	KeypairSsh  = "ssh"
+	KeypairX509 = "x509"
+)
+
+// A Keypair represents a nova keypair
+type Keypair struct {
+	Name        string                `json:"name"`
+	Type        string                `json:"type"` // microversion >= 2.2
+	PublicKey   string                `json:"public_key"`
+	PrivateKey  string                `json:"private_key"` // Only set on generation
+	Fingerprint string                `json:"fingerprint"`
+	UserId      string                `json:"user_id"`
+	CreatedAt   gopenstack.DateTimeOs `json:"created_at"`
+}
+
+// ListKeypairs returns keypairs of the user
+func (c *Compute) ListKeypairs() ([]Keypair, error) {
+	var r struct {
+		Keypairs []struct {
+			Keypair Keypair `json:"keypair"`
+		} `json:"keypairs"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "os-keypairs",
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	keypairs := make([]Keypair, len(r.Keypairs))
+	for i, k := range r.Keypairs {
+		keypairs[i] = k.Keypair
+	}
+	return keypairs, nil
+}
+
+// GetKeypair returns keypair name
+func (c *Compute) GetKeypair(name string) (*Keypair, error) {
+	var r struct {
+		Keypair Keypair `json:"keypair"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "os-keypairs/" + url.PathEscape(name),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Keypair, nil
+}
+
+// CreateKeypair generates keypair name, the private key is only returned by this call
+// keyType (KeypairSsh or KeypairX509) requires microversion >= 2.2, empty for default
+// Generation is not available from microversion 2.92, use ImportKeypair
+func (c *Compute) CreateKeypair(name, keyType string) (*Keypair, error) {
+	return c.createKeypair(name, keyType, "")
+}
+
+// ImportKeypair imports publicKey as keypair name
+func (c *Compute) ImportKeypair(name, keyType, publicKey string) (*Keypair, error) {
+	return c.createKeypair(name, keyType, publicKey)
+}
+
+// createKeypair creates keypair name, generated if publicKey is empty
+func (c *Compute) createKeypair(name, keyType, publicKey string) (*Keypair, error) {
+	keypair := map[string]string{"name": name}
+	if keyType != "" {
+		keypair["type"] = keyType
+	}
+	if publicKey != "" {
+		keypair["public_key"] = publicKey
+	}
+	var r struct {
+		Keypair Keypair `json:"keypair"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "os-keypairs",
+	}, map[string]interface{}{"keypair": keypair}, &r, []int{200, 201})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Keypair, nil
+}
+
+// DeleteKeypair deletes keypair name
+func (c *Compute) DeleteKeypair(name string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "os-keypairs/" + url.PathEscape(name),
+	}, nil, nil, []int{202, 204})
+	return err
+}
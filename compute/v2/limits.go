@@ -0,0 +1,51 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// AbsoluteLimits represents absolute limits (and usage) of a project
+type AbsoluteLimits struct {
+	MaxTotalCores           int `json:"maxTotalCores"`
+	MaxTotalInstances       int `json:"maxTotalInstances"`
+	MaxTotalRAMSize         int `json:"maxTotalRAMSize"` // MB
+	MaxTotalKeypairs        int `json:"maxTotalKeypairs"`
+	MaxServerMeta           int `json:"maxServerMeta"`
+	MaxServerGroups         int `json:"maxServerGroups"`
+	MaxServerGroupMembers   int `json:"maxServerGroupMembers"`
+	MaxPersonality          int `json:"maxPersonality"`        // before microversion 2.57
+	MaxPersonalitySize      int `json:"maxPersonalitySize"`    // before microversion 2.57
+	MaxImageMeta            int `json:"maxImageMeta"`          // before microversion 2.39
+	MaxTotalFloatingIps     int `json:"maxTotalFloatingIps"`   // before microversion 2.36
+	MaxSecurityGroups       int `json:"maxSecurityGroups"`     // before microversion 2.36
+	MaxSecurityGroupRules   int `json:"maxSecurityGroupRules"` // before microversion 2.36
+	TotalCoresUsed          int `json:"totalCoresUsed"`
+	TotalInstancesUsed      int `json:"totalInstancesUsed"`
+	TotalRAMUsed            int `json:"totalRAMUsed"` // MB
+	TotalServerGroupsUsed   int `json:"totalServerGroupsUsed"`
+	TotalFloatingIpsUsed    int `json:"totalFloatingIpsUsed"`    // before microversion 2.36
+	TotalSecurityGroupsUsed int `json:"totalSecurityGroupsUsed"` // before microversion 2.36
+}
+
+// GetLimits returns absolute limits of the project (of project projectId if set, admin only)
+func (c *Compute) GetLimits(projectId string) (*AbsoluteLimits, error) {
+	ressource := "limits"
+	if projectId != "" {
+		ressource += "?tenant_id=" + url.QueryEscape(projectId)
+	}
+	var r struct {
+		Limits struct {
+			Absolute AbsoluteLimits `json:"absolute"`
+		} `json:"limits"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: ressource,
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Limits.Absolute, nil
+}
@@ -0,0 +1,111 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// GetServerMetadata returns metadata of server id
+func (c *Compute) GetServerMetadata(id string) (map[string]string, error) {
+	var r struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id) + "/metadata",
+	}, nil, &r, []int{200})
+	return r.Metadata, err
+}
+
+// SetServerMetadata replaces all metadata of server id by metadata
+func (c *Compute) SetServerMetadata(id string, metadata map[string]string) (map[string]string, error) {
+	return c.putServerMetadata("PUT", id, metadata)
+}
+
+// UpdateServerMetadata adds or updates metadata items of server id (others are kept)
+func (c *Compute) UpdateServerMetadata(id string, metadata map[string]string) (map[string]string, error) {
+	return c.putServerMetadata("POST", id, metadata)
+}
+
+// putServerMetadata sets metadata of server id using method (PUT replaces, POST merges)
+func (c *Compute) putServerMetadata(method, id string, metadata map[string]string) (map[string]string, error) {
+	var r struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    method,
+		Ressource: "servers/" + url.PathEscape(id) + "/metadata",
+	}, map[string]interface{}{"metadata": metadata}, &r, []int{200})
+	return r.Metadata, err
+}
+
+// DeleteServerMetadataItem deletes metadata item key of server id
+func (c *Compute) DeleteServerMetadataItem(id, key string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "servers/" + url.PathEscape(id) + "/metadata/" + url.PathEscape(key),
+	}, nil, nil, []int{204})
+	return err
+}
+
+// Tags (microversion >= 2.26, requested if the client one is lower)
+
+// ListServerTags returns tags of server id
+func (c *Compute) ListServerTags(id string) ([]string, error) {
+	var r struct {
+		Tags []string `json:"tags"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "GET",
+		Ressource:    "servers/" + url.PathEscape(id) + "/tags",
+		Microversion: c.client.RequiredMicroversion("2.26"),
+	}, nil, &r, []int{200})
+	return r.Tags, err
+}
+
+// SetServerTags replaces all tags of server id by tags
+func (c *Compute) SetServerTags(id string, tags []string) ([]string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	var r struct {
+		Tags []string `json:"tags"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "PUT",
+		Ressource:    "servers/" + url.PathEscape(id) + "/tags",
+		Microversion: c.client.RequiredMicroversion("2.26"),
+	}, map[string]interface{}{"tags": tags}, &r, []int{200})
+	return r.Tags, err
+}
+
+// AddServerTag adds tag to server id
+func (c *Compute) AddServerTag(id, tag string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "PUT",
+		Ressource:    "servers/" + url.PathEscape(id) + "/tags/" + url.PathEscape(tag),
+		Microversion: c.client.RequiredMicroversion("2.26"),
+	}, nil, nil, []int{201, 204})
+	return err
+}
+
+// DeleteServerTag removes tag from server id
+func (c *Compute) DeleteServerTag(id, tag string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "DELETE",
+		Ressource:    "servers/" + url.PathEscape(id) + "/tags/" + url.PathEscape(tag),
+		Microversion: c.client.RequiredMicroversion("2.26"),
+	}, nil, nil, []int{204})
+	return err
+}
+
+// DeleteServerTags removes all tags of server id
+func (c *Compute) DeleteServerTags(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:       "DELETE",
+		Ressource:    "servers/" + url.PathEscape(id) + "/tags",
+		Microversion: c.client.RequiredMicroversion("2.26"),
+	}, nil, nil, []int{204})
+	return err
+}
@@ -0,0 +1,92 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A QuotaSet represents compute quotas of a project (-1 is unlimited)
+type QuotaSet struct {
+	Id                       string `json:"id"`
+	Cores                    int    `json:"cores"`
+	Instances                int    `json:"instances"`
+	Ram                      int    `json:"ram"` // MB
+	KeyPairs                 int    `json:"key_pairs"`
+	MetadataItems            int    `json:"metadata_items"`
+	ServerGroups             int    `json:"server_groups"`
+	ServerGroupMembers       int    `json:"server_group_members"`
+	InjectedFiles            int    `json:"injected_files"`              // before microversion 2.57
+	InjectedFileContentBytes int    `json:"injected_file_content_bytes"` // before microversion 2.57
+	InjectedFilePathBytes    int    `json:"injected_file_path_bytes"`    // before microversion 2.57
+	FixedIps                 int    `json:"fixed_ips"`                   // before microversion 2.36
+	FloatingIps              int    `json:"floating_ips"`                // before microversion 2.36
+	SecurityGroups           int    `json:"security_groups"`             // before microversion 2.36
+	SecurityGroupRules       int    `json:"security_group_rules"`        // before microversion 2.36
+}
+
+// QuotaDetail is the limit and usage of a quota
+type QuotaDetail struct {
+	Limit    int `json:"limit"`
+	InUse    int `json:"in_use"`
+	Reserved int `json:"reserved"`
+}
+
+// A QuotaSetDetail represents compute quotas of a project with their usage
+type QuotaSetDetail struct {
+	Id                       string      `json:"id"`
+	Cores                    QuotaDetail `json:"cores"`
+	Instances                QuotaDetail `json:"instances"`
+	Ram                      QuotaDetail `json:"ram"`
+	KeyPairs                 QuotaDetail `json:"key_pairs"`
+	MetadataItems            QuotaDetail `json:"metadata_items"`
+	ServerGroups             QuotaDetail `json:"server_groups"`
+	ServerGroupMembers       QuotaDetail `json:"server_group_members"`
+	InjectedFiles            QuotaDetail `json:"injected_files"`
+	InjectedFileContentBytes QuotaDetail `json:"injected_file_content_bytes"`
+	InjectedFilePathBytes    QuotaDetail `json:"injected_file_path_bytes"`
+	FixedIps                 QuotaDetail `json:"fixed_ips"`
+	FloatingIps              QuotaDetail `json:"floating_ips"`
+	SecurityGroups           QuotaDetail `json:"security_groups"`
+	SecurityGroupRules       QuotaDetail `json:"security_group_rules"`
+}
+
+// GetQuotaSet returns quotas of project projectId
+func (c *Compute) GetQuotaSet(projectId string) (*QuotaSet, error) {
+	return c.getQuotaSet("os-quota-sets/" + url.PathEscape(projectId))
+}
+
+// GetDefaultQuotaSet returns default quotas of project projectId
+func (c *Compute) GetDefaultQuotaSet(projectId string) (*QuotaSet, error) {
+	return c.getQuotaSet("os-quota-sets/" + url.PathEscape(projectId) + "/defaults")
+}
+
+// getQuotaSet returns the quota set at ressource
+func (c *Compute) getQuotaSet(ressource string) (*QuotaSet, error) {
+	var r struct {
+		QuotaSet QuotaSet `json:"quota_set"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: ressource,
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.QuotaSet, nil
+}
+
+// GetQuotaSetDetail returns quotas of project projectId with their usage
+func (c *Compute) GetQuotaSetDetail(projectId string) (*QuotaSetDetail, error) {
+	var r struct {
+		QuotaSet QuotaSetDetail `json:"quota_set"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "os-quota-sets/" + url.PathEscape(projectId) + "/detail",
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.QuotaSet, nil
+}
@@ -0,0 +1,392 @@
+package computeV2
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Server status
+const (
+	StatusActive       = "ACTIVE"
+	StatusBuild        = "BUILD"
+	StatusDeleted      = "DELETED"
+	StatusError        = "ERROR"
+	StatusHardReboot   = "HARD_REBOOT"
+	StatusPaused       = "PAUSED"
+	StatusReboot       = "REBOOT"
+	StatusRebuild      = "REBUILD"
+	StatusResize       = "RESIZE"
+	StatusShutoff      = "SHUTOFF"
+	StatusSuspended    = "SUSPENDED"
+	StatusVerifyResize = "VERIFY_RESIZE"
+)
+
+// A Server represents a nova server
+type Server struct {
+	Id               string                `json:"id"`
+	Name             string                `json:"name"`
+	Status           string                `json:"status"`
+	Description      string                `json:"description"`
+	TenantId         string                `json:"tenant_id"`
+	UserId           string                `json:"user_id"`
+	HostId           string                `json:"hostId"`
+	Image            ServerImage           `json:"image"`
+	Flavor           ServerFlavor          `json:"flavor"`
+	Addresses        map[string][]Address  `json:"addresses"`
+	AccessIPv4       string                `json:"accessIPv4"`
+	AccessIPv6       string                `json:"accessIPv6"`
+	KeyName          string                `json:"key_name"`
+	Metadata         map[string]string     `json:"metadata"`
+	SecurityGroups   []SecurityGroup       `json:"security_groups"`
+	Tags             []string              `json:"tags"`
+	Progress         int                   `json:"progress"`
+	ConfigDrive      string                `json:"config_drive"`
+	Locked           bool                  `json:"locked"`
+	Fault            *ServerFault          `json:"fault"`
+	Created          gopenstack.DateTime   `json:"created"`
+	Updated          gopenstack.DateTime   `json:"updated"`
+	Links            []gopenstack.Link     `json:"links"`
+	AvailabilityZone string                `json:"OS-EXT-AZ:availability_zone"`
+	DiskConfig       string                `json:"OS-DCF:diskConfig"`
+	Host             string                `json:"OS-EXT-SRV-ATTR:host"`
+	PowerState       int                   `json:"OS-EXT-STS:power_state"`
+	TaskState        string                `json:"OS-EXT-STS:task_state"`
+	VmState          string                `json:"OS-EXT-STS:vm_state"`
+	LaunchedAt       gopenstack.DateTimeOs `json:"OS-SRV-USG:launched_at"`
+	TerminatedAt     gopenstack.DateTimeOs `json:"OS-SRV-USG:terminated_at"`
+	AdminPass        string                `json:"adminPass"` // Only set on create and rebuild
+}
+
+// ServerImage is the image of a server (empty for servers booted from volume)
+type ServerImage struct {
+	Id    string            `json:"id"`
+	Links []gopenstack.Link `json:"links"`
+}
+
+// UnmarshalJSON handles the empty string returned for servers booted from volume
+func (i *ServerImage) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*i = ServerImage{}
+		return nil
+	}
+	type image ServerImage
+	return json.Unmarshal(data, (*image)(i))
+}
+
+// ServerFlavor is the flavor of a server
+// Before microversion 2.47 only Id is set, from 2.47 Id is empty and other fields are set
+type ServerFlavor struct {
+	Id           string            `json:"id"`
+	OriginalName string            `json:"original_name"`
+	Vcpus        int               `json:"vcpus"`
+	Ram          int               `json:"ram"`
+	Disk         int               `json:"disk"`
+	Ephemeral    int               `json:"ephemeral"`
+	Swap         int               `json:"swap"`
+	ExtraSpecs   map[string]string `json:"extra_specs"`
+}
+
+// Address is an IP address of a server
+type Address struct {
+	Version int    `json:"version"`
+	Addr    string `json:"addr"`
+	Type    string `json:"OS-EXT-IPS:type"` // fixed or floating
+	MacAddr string `json:"OS-EXT-IPS-MAC:mac_addr"`
+}
+
+// SecurityGroup is a security group of a server
+type SecurityGroup struct {
+	Name string `json:"name"`
+}
+
+// ServerFault is the last fault of a server in ERROR state
+type ServerFault struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Details string              `json:"details"`
+	Created gopenstack.DateTime `json:"created"`
+}
+
+// ServerNetwork is a network to attach to a new server
+type ServerNetwork struct {
+	Uuid    string `json:"uuid,omitempty"`
+	Port    string `json:"port,omitempty"`
+	FixedIp string `json:"fixed_ip,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+}
+
+// BlockDevice is a block device mapping of a new server
+type BlockDevice struct {
+	BootIndex           int    `json:"boot_index"`
+	Uuid                string `json:"uuid,omitempty"`
+	SourceType          string `json:"source_type"`                // image, volume, snapshot or blank
+	DestinationType     string `json:"destination_type,omitempty"` // volume or local
+	VolumeSize          int    `json:"volume_size,omitempty"`
+	VolumeType          string `json:"volume_type,omitempty"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+	DeviceName          string `json:"device_name,omitempty"`
+	Tag                 string `json:"tag,omitempty"`
+}
+
+// CreateServerOptions represents options of a new server
+type CreateServerOptions struct {
+	Name             string
+	ImageRef         string // Image id (optional when booting from volume)
+	FlavorRef        string // Flavor id
+	KeyName          string
+	AvailabilityZone string
+	AdminPass        string
+	Description      string
+	UserData         []byte // Raw user data (base64 encoded on sending)
+	Metadata         map[string]string
+	SecurityGroups   []string // Security groups names
+	Networks         []ServerNetwork
+	NetworksPolicy   string // "auto" or "none" (microversion >= 2.37), overrides Networks
+	BlockDevices     []BlockDevice
+	ConfigDrive      bool
+	Tags             []string // microversion >= 2.52
+	MinCount         int
+	MaxCount         int
+	SchedulerHints   map[string]interface{}
+}
+
+// body returns the create server request body
+func (o *CreateServerOptions) body() map[string]interface{} {
+	server := map[string]interface{}{
+		"name":      o.Name,
+		"flavorRef": o.FlavorRef,
+	}
+	if o.ImageRef != "" {
+		server["imageRef"] = o.ImageRef
+	}
+	if o.KeyName != "" {
+		server["key_name"] = o.KeyName
+	}
+	if o.AvailabilityZone != "" {
+		server["availability_zone"] = o.AvailabilityZone
+	}
+	if o.AdminPass != "" {
+		server["adminPass"] = o.AdminPass
+	}
+	if o.Description != "" {
+		server["description"] = o.Description
+	}
+	if len(o.UserData) != 0 {
+		server["user_data"] = o.UserData
+	}
+	if len(o.Metadata) != 0 {
+		server["metadata"] = o.Metadata
+	}
+	if len(o.SecurityGroups) != 0 {
+		groups := make([]SecurityGroup, len(o.SecurityGroups))
+		for i, name := range o.SecurityGroups {
+			groups[i].Name = name
+		}
+		server["security_groups"] = groups
+	}
+	if o.NetworksPolicy != "" {
+		server["networks"] = o.NetworksPolicy
+	} else if len(o.Networks) != 0 {
+		server["networks"] = o.Networks
+	}
+	if len(o.BlockDevices) != 0 {
+		server["block_device_mapping_v2"] = o.BlockDevices
+	}
+	if o.ConfigDrive {
+		server["config_drive"] = true
+	}
+	if len(o.Tags) != 0 {
+		server["tags"] = o.Tags
+	}
+	if o.MinCount > 0 {
+		server["min_count"] = o.MinCount
+	}
+	if o.MaxCount > 0 {
+		server["max_count"] = o.MaxCount
+	}
+	body := map[string]interface{}{"server": server}
+	if len(o.SchedulerHints) != 0 {
+		body["os:scheduler_hints"] = o.SchedulerHints
+	}
+	return body
+}
+
+// CreateServer creates a server, the returned server is in BUILD state
+// and only has its id, links and admin password set
+func (c *Compute) CreateServer(options *CreateServerOptions) (*Server, error) {
+	var r struct {
+		Server Server `json:"server"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "servers",
+	}, options.body(), &r, []int{202})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Server, nil
+}
+
+// GetServer returns server id
+func (c *Compute) GetServer(id string) (*Server, error) {
+	var r struct {
+		Server Server `json:"server"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/" + url.PathEscape(id),
+	}, nil, &r, []int{200, 203})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Server, nil
+}
+
+// ListServersOptions represents filters and pagination of servers listing
+type ListServersOptions struct {
+	Name         string // Regular expression
+	Status       string
+	Image        string // Image id
+	Flavor       string // Flavor id
+	Host         string // Admin only
+	Ip           string // Regular expression
+	Tags         []string
+	ChangesSince time.Time
+	AllTenants   bool // Admin only
+	TenantId     string
+	Limit        int    // Page size
+	Marker       string // Id of the last server of the previous page
+}
+
+// query returns the query string corresponding to options
+func (o *ListServersOptions) query() string {
+	v := url.Values{}
+	if o == nil {
+		return ""
+	}
+	if o.Name != "" {
+		v.Set("name", o.Name)
+	}
+	if o.Status != "" {
+		v.Set("status", o.Status)
+	}
+	if o.Image != "" {
+		v.Set("image", o.Image)
+	}
+	if o.Flavor != "" {
+		v.Set("flavor", o.Flavor)
+	}
+	if o.Host != "" {
+		v.Set("host", o.Host)
+	}
+	if o.Ip != "" {
+		v.Set("ip", o.Ip)
+	}
+	if len(o.Tags) != 0 {
+		v.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if !o.ChangesSince.IsZero() {
+		v.Set("changes-since", o.ChangesSince.UTC().Format(time.RFC3339))
+	}
+	if o.AllTenants {
+		v.Set("all_tenants", "1")
+	}
+	if o.TenantId != "" {
+		v.Set("tenant_id", o.TenantId)
+	}
+	return gopenstack.PageQuery(v, o.Limit, o.Marker)
+}
+
+// ListServersPage returns a page of servers (with details) and the marker
+// of the next page (empty on the last page)
+func (c *Compute) ListServersPage(options *ListServersOptions) (servers []Server, next string, err error) {
+	var r struct {
+		Servers []Server          `json:"servers"`
+		Links   []gopenstack.Link `json:"servers_links"`
+	}
+	_, err = c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "servers/detail" + options.query(),
+	}, nil, &r, []int{200, 203})
+	if err != nil {
+		return
+	}
+	return r.Servers, gopenstack.NextMarker(r.Links), nil
+}
+
+// ListServers returns all servers (with details) matching options, following pages
+func (c *Compute) ListServers(options *ListServersOptions) (servers []Server, err error) {
+	o := ListServersOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		page, next, err := c.ListServersPage(&o)
+		if err != nil {
+			return servers, err
+		}
+		servers = append(servers, page...)
+		if next == "" || len(page) == 0 {
+			return servers, nil
+		}
+		o.Marker = next
+	}
+}
+
+// UpdateServerOptions represents updatable server attributes, empty ones are not updated
+type UpdateServerOptions struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"` // microversion >= 2.19
+	AccessIPv4  string `json:"accessIPv4,omitempty"`
+	AccessIPv6  string `json:"accessIPv6,omitempty"`
+}
+
+// UpdateServer updates server id attributes
+func (c *Compute) UpdateServer(id string, options *UpdateServerOptions) (*Server, error) {
+	var r struct {
+		Server Server `json:"server"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: "servers/" + url.PathEscape(id),
+	}, map[string]interface{}{"server": options}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Server, nil
+}
+
+// DeleteServer deletes server id (deletion is asynchronous)
+func (c *Compute) DeleteServer(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "servers/" + url.PathEscape(id),
+	}, nil, nil, []int{204})
+	return err
+}
+
+// ServerIdFromName returns the id of server nameOrId
+// (nameOrId is returned if it is the id of a server)
+func (c *Compute) ServerIdFromName(nameOrId string) (string, error) {
+	servers, err := c.ListServers(&ListServersOptions{Name: "^" + regexp.QuoteMeta(nameOrId) + "$"})
+	if err != nil {
+		return "", err
+	}
+	candidates := make([]namedResource, len(servers))
+	for i, s := range servers {
+		candidates[i] = namedResource{s.Id, s.Name}
+	}
+	id, err := resolveId("server", nameOrId, candidates)
+	if _, notFound := err.(*gopenstack.ResourceNotFoundError); notFound {
+		// nameOrId may be an id
+		if s, err := c.GetServer(nameOrId); err == nil {
+			return s.Id, nil
+		}
+	}
+	return id, err
+}
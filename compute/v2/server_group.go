@@ -0,0 +1,107 @@
+package computeV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Server group policies
+const (
+	PolicyAffinity         = "affinity"
+	PolicyAntiAffinity     = "anti-affinity"
+	PolicySoftAffinity     = "soft-affinity"      // microversion >= 2.15
+	PolicySoftAntiAffinity = "soft-anti-affinity" // microversion >= 2.15
+)
+
+// A ServerGroup represents a nova server group
+// Servers are added to a group on creation with the "group" scheduler hint
+type ServerGroup struct {
+	Id        string            `json:"id"`
+	Name      string            `json:"name"`
+	Policy    string            `json:"policy"`   // set from Policies before microversion 2.64
+	Policies  []string          `json:"policies"` // before microversion 2.64
+	Rules     map[string]int    `json:"rules"`    // microversion >= 2.64 (eg max_server_per_host)
+	Members   []string          `json:"members"`
+	Metadata  map[string]string `json:"metadata"`
+	ProjectId string            `json:"project_id"`
+	UserId    string            `json:"user_id"`
+}
+
+// normalize sets Policy from Policies
+func (g *ServerGroup) normalize() {
+	if g.Policy == "" && len(g.Policies) != 0 {
+		g.Policy = g.Policies[0]
+	}
+}
+
+// ListServerGroups returns server groups of the project
+// (of all projects if allProjects is set, admin only)
+func (c *Compute) ListServerGroups(allProjects bool) ([]ServerGroup, error) {
+	ressource := "os-server-groups"
+	if allProjects {
+		ressource += "?all_projects=true"
+	}
+	var r struct {
+		ServerGroups []ServerGroup `json:"server_groups"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: ressource,
+	}, nil, &r, []int{200})
+	for i := range r.ServerGroups {
+		r.ServerGroups[i].normalize()
+	}
+	return r.ServerGroups, err
+}
+
+// GetServerGroup returns server group id
+func (c *Compute) GetServerGroup(id string) (*ServerGroup, error) {
+	var r struct {
+		ServerGroup ServerGroup `json:"server_group"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "os-server-groups/" + url.PathEscape(id),
+	}, nil, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	r.ServerGroup.normalize()
+	return &r.ServerGroup, nil
+}
+
+// CreateServerGroup creates server group name with policy (eg PolicyAntiAffinity)
+// rules (eg {"max_server_per_host": 2}) require client microversion >= 2.64
+func (c *Compute) CreateServerGroup(name, policy string, rules map[string]int) (*ServerGroup, error) {
+	group := map[string]interface{}{"name": name}
+	if c.client.MicroversionAtLeast("2.64") {
+		group["policy"] = policy
+		if len(rules) != 0 {
+			group["rules"] = rules
+		}
+	} else {
+		group["policies"] = []string{policy}
+	}
+	var r struct {
+		ServerGroup ServerGroup `json:"server_group"`
+	}
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: "os-server-groups",
+	}, map[string]interface{}{"server_group": group}, &r, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	r.ServerGroup.normalize()
+	return &r.ServerGroup, nil
+}
+
+// DeleteServerGroup deletes server group id
+func (c *Compute) DeleteServerGroup(id string) error {
+	_, err := c.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: "os-server-groups/" + url.PathEscape(id),
+	}, nil, nil, []int{204})
+	return err
+}
@@ -0,0 +1,119 @@
+package computeV2
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A TenantUsage represents the usage of a project over a time range
+type TenantUsage struct {
+	TenantId           string                `json:"tenant_id"`
+	Start              gopenstack.DateTimeOs `json:"start"`
+	Stop               gopenstack.DateTimeOs `json:"stop"`
+	TotalHours         float64               `json:"total_hours"`
+	TotalVcpusUsage    float64               `json:"total_vcpus_usage"`     // vCPU hours
+	TotalMemoryMbUsage float64               `json:"total_memory_mb_usage"` // MB hours
+	TotalLocalGbUsage  float64               `json:"total_local_gb_usage"`  // GB hours
+	ServerUsages       []ServerUsage         `json:"server_usages"`         // detailed only
+}
+
+// A ServerUsage represents the usage of a server over a time range
+type ServerUsage struct {
+	InstanceId string                `json:"instance_id"`
+	Name       string                `json:"name"`
+	TenantId   string                `json:"tenant_id"`
+	Flavor     string                `json:"flavor"`
+	State      string                `json:"state"`
+	Hours      float64               `json:"hours"`
+	Vcpus      int                   `json:"vcpus"`
+	MemoryMb   int                   `json:"memory_mb"`
+	LocalGb    int                   `json:"local_gb"`
+	Uptime     int                   `json:"uptime"` // seconds
+	StartedAt  gopenstack.DateTimeOs `json:"started_at"`
+	EndedAt    gopenstack.DateTimeOs `json:"ended_at"`
+}
+
+// add merges a page of usage u2 in u
+func (u *TenantUsage) add(u2 *TenantUsage) {
+	u.TotalHours += u2.TotalHours
+	u.TotalVcpusUsage += u2.TotalVcpusUsage
+	u.TotalMemoryMbUsage += u2.TotalMemoryMbUsage
+	u.TotalLocalGbUsage += u2.TotalLocalGbUsage
+	u.ServerUsages = append(u.ServerUsages, u2.ServerUsages...)
+}
+
+// usageQuery returns the query of a usage between start and end
+func usageQuery(start, end time.Time, detailed bool, marker string) string {
+	v := url.Values{}
+	v.Set("start", gopenstack.FormatDateTimeOs(start))
+	v.Set("end", gopenstack.FormatDateTimeOs(end))
+	if detailed {
+		v.Set("detailed", "1")
+	}
+	if marker != "" {
+		v.Set("marker", marker)
+	}
+	return "?" + v.Encode()
+}
+
+// GetTenantUsage returns usage (with servers usages) of project projectId between start and end
+// Pages (microversion >= 2.40) are merged
+func (c *Compute) GetTenantUsage(projectId string, start, end time.Time) (*TenantUsage, error) {
+	var usage *TenantUsage
+	marker := ""
+	for {
+		var r struct {
+			TenantUsage TenantUsage       `json:"tenant_usage"`
+			Links       []gopenstack.Link `json:"tenant_usage_links"`
+		}
+		_, err := c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "os-simple-tenant-usage/" + url.PathEscape(projectId) + usageQuery(start, end, false, marker),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return nil, err
+		}
+		if usage == nil {
+			usage = &r.TenantUsage
+		} else {
+			usage.add(&r.TenantUsage)
+		}
+		if marker = gopenstack.NextMarker(r.Links); marker == "" || len(r.TenantUsage.ServerUsages) == 0 {
+			return usage, nil
+		}
+	}
+}
+
+// ListTenantUsages returns usages of all projects between start and end (admin only)
+// If detailed is set servers usages are returned. Pages (microversion >= 2.40) are merged
+func (c *Compute) ListTenantUsages(start, end time.Time, detailed bool) ([]TenantUsage, error) {
+	var usages []TenantUsage
+	index := make(map[string]int)
+	marker := ""
+	for {
+		var r struct {
+			TenantUsages []TenantUsage     `json:"tenant_usages"`
+			Links        []gopenstack.Link `json:"tenant_usages_links"`
+		}
+		_, err := c.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: "os-simple-tenant-usage" + usageQuery(start, end, detailed, marker),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range r.TenantUsages {
+			if i, ok := index[u.TenantId]; ok {
+				usages[i].add(&u)
+				continue
+			}
+			index[u.TenantId] = len(usages)
+			usages = append(usages, u)
+		}
+		if marker = gopenstack.NextMarker(r.Links); marker == "" || len(r.TenantUsages) == 0 {
+			return usages, nil
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package computeV2
+
+import (
+	"context"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// WaitForServerStatus waits until server id reaches status (eg StatusActive)
+// It fails if the server goes in ERROR status (the server fault is the reason)
+func (c *Compute) WaitForServerStatus(ctx context.Context, id, status string) (*Server, error) {
+	var server *Server
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		s, err := c.GetServer(id)
+		if err != nil {
+			return "", err
+		}
+		server = s
+		return s.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{status}})
+	if se, ok := err.(*gopenstack.StatusError); ok && server.Fault != nil {
+		se.Reason = server.Fault.Message
+	}
+	return server, err
+}
+
+// WaitForServerDeleted waits until server id is deleted
+func (c *Compute) WaitForServerDeleted(ctx context.Context, id string) error {
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		s, err := c.GetServer(id)
+		if err != nil {
+			return "", err
+		}
+		return s.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{StatusDeleted}, NotFoundIsTarget: true})
+	return err
+}
@@ -0,0 +1,46 @@
+package computeV2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/gopenstacktest"
+)
+
+// newTestServerCompute returns a Compute whose server s1 is answered by body (404 if empty)
+func newTestServerCompute(t *testing.T, body string) *Compute {
+	return NewCompute(gopenstacktest.NewClient(t, "compute", "/v2.1", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2.1/servers/s1" || body == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestWaitForServerStatus(t *testing.T) {
+	c := newTestServerCompute(t, `{"server": {"id": "s1", "status": "ACTIVE"}}`)
+	if s, err := c.WaitForServerStatus(context.Background(), "s1", StatusActive); err != nil || s.Id != "s1" {
+		t.Errorf("active server: %+v, %v", s, err)
+	}
+
+	c = newTestServerCompute(t, `{"server": {"id": "s1", "status": "ERROR", "fault": {"code": 500, "message": "No valid host was found"}}}`)
+	_, err := c.WaitForServerStatus(context.Background(), "s1", StatusActive)
+	var statusErr *gopenstack.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != "ERROR" || statusErr.Reason != "No valid host was found" {
+		t.Errorf("server in error: %v", err)
+	}
+}
+
+func TestWaitForServerDeleted(t *testing.T) {
+	if err := newTestServerCompute(t, "").WaitForServerDeleted(context.Background(), "s1"); err != nil {
+		t.Errorf("deleted server: %v", err)
+	}
+	c := newTestServerCompute(t, `{"server": {"id": "s1", "status": "ERROR"}}`)
+	if err := c.WaitForServerDeleted(context.Background(), "s1"); err == nil {
+		t.Errorf("server in error is not deleted")
+	}
+}
@@ -2,6 +2,9 @@ package gopenstack
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 )
 
 var (
@@ -10,12 +13,191 @@ var (
 	ErrContainerNotFound          = errors.New("Container not found")
 	ErrCopyLocalToLocalNotAllowed = errors.New("Local copies ares not allowed")
 	ErrNoContainerSpecified       = errors.New("You must specify a container")
+	ErrBulkDeleteNotAvailable     = errors.New("Bulk delete is not available on this cluster")
+	ErrVersioningNotAvailable     = errors.New("Object versioning is not available on this cluster")
+	ErrLegacyVersioning           = errors.New("Container uses legacy versioning, versions are objects of its location container")
+	ErrNoObjectSpecified          = errors.New("You must specify an object")
+	ErrSymlinkNotAvailable        = errors.New("Symlinks are not available on this cluster")
+	ErrStaticSymlinkNotAvailable  = errors.New("Static symlinks are not available on this cluster")
+	ErrSymlinkLoop                = errors.New("Too many levels of symbolic links")
+	ErrTempURLNotAvailable        = errors.New("Temporary URLs are not available on this cluster")
+	ErrNoTempURLKey               = errors.New("No Temp-URL-Key set on this account")
+	ErrNoImageId                  = errors.New("No image id returned")
+	ErrDecryption                 = errors.New("Unable to decrypt object (bad key or corrupted data)")
+	ErrNoKeyProvider              = errors.New("Object is encrypted and no key provider is set")
+	// Network
+	ErrPortWithoutProtocol = errors.New("A protocol (tcp, udp...) is required to open a port")
 )
 
+// HttpError is returned on unexpected HTTP code
+type HttpError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HttpError) Error() string {
+	return fmt.Sprintf("%d - %s", e.StatusCode, e.Status)
+}
+
+// StatusError is returned when a resource reaches a failure status
+type StatusError struct {
+	Status string
+	Reason string // Fault message (if known)
+}
+
+func (e *StatusError) Error() string {
+	if e.Reason != "" {
+		return "Resource in " + e.Status + " status: " + e.Reason
+	}
+	return "Resource in " + e.Status + " status"
+}
+
+// ResourceNotFoundError is returned when a resource can not be found by name or id
+type ResourceNotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *ResourceNotFoundError) Error() string {
+	return e.Kind + " " + e.Name + " not found"
+}
+
+// PathNotFoundError is returned when a path does not exist
+// It matches os.ErrNotExist (errors.Is)
+type PathNotFoundError struct {
+	Path string
+}
+
+func (e *PathNotFoundError) Error() string {
+	return e.Path + ": No such file or directory "
+}
+
+func (e *PathNotFoundError) Unwrap() error {
+	return os.ErrNotExist
+}
+
 func ErrPathNotFound(path string) error {
-	return errors.New(path + ": No such file or directory ")
+	return &PathNotFoundError{path}
+}
+
+func ErrNotADirectory(path string) error {
+	return errors.New(path + ": Not a directory")
+}
+
+func ErrNotAFile(path string) error {
+	return errors.New(path + ": Is a directory")
 }
 
 func ErrUnsuportedPathType(pathType string) error {
 	return errors.New(pathType + ": Unsuported path type")
 }
+
+func ErrBulkFailed(status string, failures [][]string) error {
+	msg := "Bulk operation failed: " + status
+	for _, f := range failures {
+		msg += "\n" + strings.Join(f, " - ")
+	}
+	return errors.New(msg)
+}
+
+func ErrUnsupportedArchiveFormat(format string) error {
+	return errors.New(format + ": Unsupported archive format")
+}
+
+func ErrNameTooLong(name string, max int) error {
+	return fmt.Errorf("%s: Name too long (max %d)", name, max)
+}
+
+func ErrObjectTooLarge(size, max int64) error {
+	return fmt.Errorf("Object too large: %d bytes (max %d)", size, max)
+}
+
+// InvalidPathError is returned when parsing a malformed object storage path
+type InvalidPathError struct {
+	Path   string
+	Reason string
+}
+
+func (e *InvalidPathError) Error() string {
+	return e.Path + ": Invalid path (" + e.Reason + ")"
+}
+
+func ErrInvalidPath(path, reason string) error {
+	return &InvalidPathError{path, reason}
+}
+
+// CapabilitiesError is returned by features depending on a middleware
+// when the cluster capabilities (/info) can not be fetched
+type CapabilitiesError struct {
+	Err error
+}
+
+func (e *CapabilitiesError) Error() string {
+	return "Unable to get cluster capabilities: " + e.Err.Error()
+}
+
+func (e *CapabilitiesError) Unwrap() error {
+	return e.Err
+}
+
+func ErrCapabilities(err error) error {
+	return &CapabilitiesError{err}
+}
+
+// ChecksumMismatchError is returned when transferred data do not match their checksum
+type ChecksumMismatchError struct {
+	Path     string
+	Expected string
+	Got      string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s: Checksum mismatch (expected %s, got %s)", e.Path, e.Expected, e.Got)
+}
+
+func ErrChecksumMismatch(path, expected, got string) error {
+	return &ChecksumMismatchError{path, expected, got}
+}
+
+func ErrUnknownKey(keyId string) error {
+	return errors.New(keyId + ": Unknown encryption key")
+}
+
+func ErrUnsupportedEncryption(version, alg string) error {
+	return fmt.Errorf("Unsupported encryption (version %q, algorithm %q)", version, alg)
+}
+
+func ErrUnsupportedCompression(algorithm string) error {
+	return errors.New(algorithm + ": Unsupported compression algorithm")
+}
+
+func ErrVersionNotFound(endpoint string) error {
+	return errors.New(endpoint + ": No API version found in version document")
+}
+
+func ErrMicroversionNotSupported(service, microversion string) error {
+	return fmt.Errorf("Microversion %s not supported by %s service", microversion, service)
+}
+
+func ErrInvalidMicroversion(microversion string) error {
+	return errors.New(microversion + ": Invalid microversion")
+}
+
+func ErrResourceNotFound(kind, name string) error {
+	return &ResourceNotFoundError{kind, name}
+}
+
+// AmbiguousNameError is returned when several resources have the name used to find one
+type AmbiguousNameError struct {
+	Kind string
+	Name string
+	Ids  []string // Ids of the candidates
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%d %ss named %s (%s), use an id", len(e.Ids), e.Kind, e.Name, strings.Join(e.Ids, ", "))
+}
+
+func ErrAmbiguousName(kind, name string, ids []string) error {
+	return &AmbiguousNameError{kind, name, ids}
+}
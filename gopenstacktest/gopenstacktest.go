@@ -0,0 +1,32 @@
+// Package gopenstacktest provides helpers to test code using gopenstack
+// clients against a local HTTP server.
+package gopenstacktest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Region is the region of the endpoints of test clients
+const Region = "R1"
+
+// NewClient starts a server answering with handler and returns a client of
+// its serviceType endpoint (server URL followed by path, eg /v2.1)
+// The server is closed when the test ends
+func NewClient(t testing.TB, serviceType, path string, handler http.HandlerFunc) *gopenstack.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	keyring := &gopenstack.Keyring{Token: gopenstack.Token{Catalog: []gopenstack.Catalog{{
+		Type:      serviceType,
+		Endpoints: []gopenstack.Endpoint{{Interface: "public", Region: Region, Url: srv.URL + path}},
+	}}}}
+	client, err := gopenstack.NewClient(keyring, Region, serviceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
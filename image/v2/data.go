@@ -0,0 +1,143 @@
+package imageV2
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// DefaultHashAlgo is the os_hash_algo of glance default configuration
+// The algorithm of an image is only known once its data are uploaded,
+// uploads are hashed with it (os_hash_value is not checked if it differs)
+const DefaultHashAlgo = "sha512"
+
+// newHasher returns a hash of os_hash_algo algo, nil if unsupported
+func newHasher(algo string) hash.Hash {
+	switch algo {
+	case "md5":
+		return md5.New()
+	case "sha256":
+		return sha256.New()
+	case "sha384":
+		return sha512.New384()
+	case "sha512":
+		return sha512.New()
+	}
+	return nil
+}
+
+// A hashingReader hashes data read from r with md5 (checksum) and algo (os_hash_algo)
+type hashingReader struct {
+	r      io.Reader
+	md5    hash.Hash
+	algo   string
+	hasher hash.Hash // nil if algo is unsupported
+	size   int64
+}
+
+func newHashingReader(r io.Reader, algo string) *hashingReader {
+	return &hashingReader{r: r, md5: md5.New(), algo: algo, hasher: newHasher(algo)}
+}
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	h.md5.Write(p[:n])
+	if h.hasher != nil {
+		h.hasher.Write(p[:n])
+	}
+	h.size += int64(n)
+	return n, err
+}
+
+// check returns an error if read data do not match image checksums
+// (os_hash_value of another or an unsupported algorithm is not checked)
+func (h *hashingReader) check(image *Image) error {
+	if image.Size != 0 && h.size != image.Size {
+		return gopenstack.ErrChecksumMismatch(image.Id, fmt.Sprintf("%d bytes", image.Size), fmt.Sprintf("%d bytes", h.size))
+	}
+	if image.Checksum != "" {
+		if got := fmt.Sprintf("%x", h.md5.Sum(nil)); got != image.Checksum {
+			return gopenstack.ErrChecksumMismatch(image.Id, image.Checksum, got)
+		}
+	}
+	if h.hasher != nil && image.OsHashAlgo == h.algo && image.OsHashValue != "" {
+		if got := fmt.Sprintf("%x", h.hasher.Sum(nil)); got != image.OsHashValue {
+			return gopenstack.ErrChecksumMismatch(image.Id, image.OsHashValue, got)
+		}
+	}
+	return nil
+}
+
+// UploadImageData uploads (streams) data of the queued image id
+// Checksums computed by glance are then verified against uploaded data,
+// the returned image is the active one
+func (g *Glance) UploadImageData(id string, data io.Reader) (*Image, error) {
+	h := newHashingReader(data, DefaultHashAlgo)
+	resp, err := g.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/file",
+		Headers:   map[string]string{"Content-Type": "application/octet-stream"},
+		Payload:   h,
+	})
+	if err = resp.HandleErr(err, []int{204}); err != nil {
+		return nil, err
+	}
+	image, err := g.GetImage(id)
+	if err != nil {
+		return nil, err
+	}
+	return image, h.check(image)
+}
+
+// A downloadReader verifies downloaded data against image checksums at EOF
+type downloadReader struct {
+	*hashingReader
+	body  io.ReadCloser
+	image *Image
+}
+
+func (d *downloadReader) Read(p []byte) (int, error) {
+	n, err := d.hashingReader.Read(p)
+	if err == io.EOF {
+		if cerr := d.check(d.image); cerr != nil {
+			return n, cerr
+		}
+	}
+	return n, err
+}
+
+func (d *downloadReader) Close() error {
+	return d.body.Close()
+}
+
+// DownloadImageData returns a reader streaming data of image id
+// Data are verified against image checksums: reading ends with an error
+// instead of io.EOF on mismatch. The reader must be closed.
+func (g *Glance) DownloadImageData(id string) (io.ReadCloser, error) {
+	image, err := g.GetImage(id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.client.Call(&gopenstack.CallOptions{
+		Method:             "GET",
+		Ressource:          g.prefix + "images/" + url.PathEscape(id) + "/file",
+		ReturnBodyAsReader: true,
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		if resp.BodyReader != nil {
+			resp.BodyReader.Close()
+		}
+		return nil, err
+	}
+	return &downloadReader{
+		hashingReader: newHashingReader(resp.BodyReader, image.OsHashAlgo),
+		body:          resp.BodyReader,
+		image:         image,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package imageV2
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/gopenstacktest"
+)
+
+// newTestGlance returns a Glance using an image service answering with handler
+func newTestGlance(t *testing.T, handler http.HandlerFunc) *Glance {
+	return NewGlance(gopenstacktest.NewClient(t, "image", "", handler))
+}
+
+func TestPatchOperationJSON(t *testing.T) {
+	data, err := json.Marshal([]PatchOperation{Add("min_ram", 0), Replace("protected", false), Remove("os_distro")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"op":"add","path":"/min_ram","value":0},{"op":"replace","path":"/protected","value":false},{"op":"remove","path":"/os_distro"}]`
+	if string(data) != expected {
+		t.Errorf("patch: %s", data)
+	}
+}
+
+func TestStagedImport(t *testing.T) {
+	content := "image data"
+	for _, tc := range []struct {
+		name      string
+		stored    string // data imported by glance
+		algo      string
+		corrupted bool
+	}{
+		{"ok", content, "sha512", false},
+		{"corrupted", "other data", "sha512", true},
+		{"other algo", content, "sha256", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var staged string
+			g := newTestGlance(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "PUT" && r.URL.Path == "/v2/images/i1/stage":
+					data, _ := ioutil.ReadAll(r.Body)
+					staged = string(data)
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == "POST" && r.URL.Path == "/v2/images/i1/import":
+					w.WriteHeader(http.StatusAccepted)
+				case r.Method == "GET" && r.URL.Path == "/v2/images/i1":
+					hashValue := fmt.Sprintf("%x", sha512.Sum512([]byte(tc.stored)))
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"id": "i1", "status": "active", "size": len(tc.stored),
+						"checksum":     fmt.Sprintf("%x", md5.Sum([]byte(tc.stored))),
+						"os_hash_algo": tc.algo, "os_hash_value": hashValue,
+					})
+				default:
+					http.NotFound(w, r)
+				}
+			})
+
+			s, err := g.StageImageData("i1", strings.NewReader(content))
+			if err != nil || staged != content {
+				t.Fatalf("StageImageData: %q, %v", staged, err)
+			}
+			if err = g.ImportStagedImage("i1"); err != nil {
+				t.Fatal(err)
+			}
+			_, err = g.WaitForImport(context.Background(), s)
+			var mismatch *gopenstack.ChecksumMismatchError
+			if tc.corrupted != errors.As(err, &mismatch) || (!tc.corrupted && err != nil) {
+				t.Errorf("WaitForImport: %v", err)
+			}
+		})
+	}
+}
+
+func TestWaitForFailedImport(t *testing.T) {
+	g := newTestGlance(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": "i1", "status": "uploading", "os_glance_failed_import": "store1,store2",
+			"os_glance_importing_to_stores": "",
+		})
+	})
+	_, err := g.WaitForImport(context.Background(), &StagedData{Id: "i1"})
+	var statusErr *gopenstack.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != "uploading" || !strings.Contains(statusErr.Reason, "store1,store2") {
+		t.Errorf("WaitForImport of a failed import: %v", err)
+	}
+}
+
+func TestImportFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		status       string
+		properties   map[string]string
+		wasImporting bool
+		failed       bool
+	}{
+		{"importing", StatusImporting, map[string]string{propImportingToStores: "store1"}, false, false},
+		{"partially failed, still importing", StatusImporting, map[string]string{propImportingToStores: "store2", propFailedImport: "store1"}, true, false},
+		{"failed stores", StatusQueued, map[string]string{propFailedImport: "store1"}, false, true},
+		{"back to uploading", StatusUploading, nil, true, true},
+		{"back to queued", StatusQueued, nil, true, true},
+		{"not imported yet", StatusQueued, nil, false, false},
+	} {
+		err := importFailure(&Image{Status: tc.status, Properties: tc.properties}, tc.wasImporting)
+		var statusErr *gopenstack.StatusError
+		if tc.failed != errors.As(err, &statusErr) || (!tc.failed && err != nil) {
+			t.Errorf("%s: importFailure = %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package imageV2
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Glance is a high-level representation of the openstack image service (glance v2)
+// The client must be created with the "image" catalog type
+type Glance struct {
+	client *gopenstack.Client
+	prefix string // API version path, empty if the endpoint is versioned
+}
+
+// NewGlance returns a Glance
+func NewGlance(client *gopenstack.Client) *Glance {
+	g := &Glance{client: client}
+	// glance catalog endpoints are usually unversioned
+	if !strings.HasSuffix(strings.TrimSuffix(client.GetEndpoint(), "/"), "/v2") {
+		g.prefix = "v2/"
+	}
+	return g
+}
+
+// ListOptions represents filters and pagination of image listings
+type ListOptions struct {
+	// Filters on images attributes (eg name, status, visibility, owner,
+	// member_status, size_min, os_hidden...)
+	Filters url.Values
+	Tags    []string // Images having all tags
+	Sort    string   // eg "name:asc,created_at:desc"
+	Limit   int      // Page size
+	Marker  string   // Id of the last image of the previous page
+}
+
+// query returns the query string corresponding to options
+func (o *ListOptions) query() string {
+	v := url.Values{}
+	if o == nil {
+		return ""
+	}
+	for k, values := range o.Filters {
+		for _, value := range values {
+			v.Add(k, value)
+		}
+	}
+	for _, tag := range o.Tags {
+		v.Add("tag", tag)
+	}
+	if o.Sort != "" {
+		v.Set("sort", o.Sort)
+	}
+	return gopenstack.PageQuery(v, o.Limit, o.Marker)
+}
+
+// Filter returns options filtering attribute on values
+func Filter(attribute string, values ...string) *ListOptions {
+	return &ListOptions{Filters: url.Values{attribute: values}}
+}
+
+// nextMarker returns the marker of the next page href (empty if none)
+func nextMarker(next string) string {
+	if next == "" {
+		return ""
+	}
+	return gopenstack.NextMarker([]gopenstack.Link{{Rel: "next", Href: next}})
+}
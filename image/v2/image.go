@@ -0,0 +1,210 @@
+package imageV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Image status
+const (
+	StatusQueued        = "queued"
+	StatusSaving        = "saving"
+	StatusUploading     = "uploading"
+	StatusImporting     = "importing"
+	StatusActive        = "active"
+	StatusDeactivated   = "deactivated"
+	StatusKilled        = "killed"
+	StatusDeleted       = "deleted"
+	StatusPendingDelete = "pending_delete"
+)
+
+// Image visibility
+const (
+	VisibilityPublic    = "public"
+	VisibilityPrivate   = "private"
+	VisibilityShared    = "shared"
+	VisibilityCommunity = "community"
+)
+
+// An Image represents a glance image
+type Image struct {
+	Id              string              `json:"id"`
+	Name            string              `json:"name"`
+	Status          string              `json:"status"`
+	Visibility      string              `json:"visibility"`
+	Protected       bool                `json:"protected"`
+	OsHidden        bool                `json:"os_hidden"`
+	Owner           string              `json:"owner"`
+	DiskFormat      string              `json:"disk_format"`
+	ContainerFormat string              `json:"container_format"`
+	Size            int64               `json:"size"`         // Bytes, 0 until data is uploaded
+	VirtualSize     int64               `json:"virtual_size"` // Bytes
+	MinDisk         int                 `json:"min_disk"`     // GB
+	MinRam          int                 `json:"min_ram"`      // MB
+	Checksum        string              `json:"checksum"`     // md5 of data
+	OsHashAlgo      string              `json:"os_hash_algo"` // eg sha512
+	OsHashValue     string              `json:"os_hash_value"`
+	Tags            []string            `json:"tags"`
+	Stores          string              `json:"stores"` // comma separated stores (multi stores deployments)
+	CreatedAt       gopenstack.DateTime `json:"created_at"`
+	UpdatedAt       gopenstack.DateTime `json:"updated_at"`
+	File            string              `json:"file"`
+	Self            string              `json:"self"`
+	Schema          string              `json:"schema"`
+
+	// Properties are the additional (custom) properties of the image, eg os_distro
+	// Non string values are kept as JSON
+	Properties map[string]string `json:"-"`
+}
+
+// imageAttributes are the JSON names of the base attributes of an image
+var imageAttributes = map[string]bool{
+	"id": true, "name": true, "status": true, "visibility": true, "protected": true,
+	"os_hidden": true, "owner": true, "disk_format": true, "container_format": true,
+	"size": true, "virtual_size": true, "min_disk": true, "min_ram": true, "checksum": true,
+	"os_hash_algo": true, "os_hash_value": true, "tags": true, "stores": true,
+	"created_at": true, "updated_at": true, "file": true, "self": true, "schema": true,
+	"locations": true, "direct_url": true,
+}
+
+// UnmarshalJSON sets additional properties in Properties
+func (i *Image) UnmarshalJSON(data []byte) error {
+	type image Image
+	if err := json.Unmarshal(data, (*image)(i)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	i.Properties = make(map[string]string)
+	for k, raw := range all {
+		if imageAttributes[k] {
+			continue
+		}
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			i.Properties[k] = s
+		} else {
+			i.Properties[k] = string(raw)
+		}
+	}
+	return nil
+}
+
+// CreateImageOptions represents options of a new image
+type CreateImageOptions struct {
+	Id              string            `json:"id,omitempty"` // Generated if empty
+	Name            string            `json:"name,omitempty"`
+	Visibility      string            `json:"visibility,omitempty"`
+	Protected       *bool             `json:"protected,omitempty"`
+	OsHidden        *bool             `json:"os_hidden,omitempty"`
+	DiskFormat      string            `json:"disk_format,omitempty"`      // eg qcow2, raw
+	ContainerFormat string            `json:"container_format,omitempty"` // eg bare
+	MinDisk         int               `json:"min_disk,omitempty"`
+	MinRam          int               `json:"min_ram,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	Properties      map[string]string `json:"-"`
+}
+
+// body returns the JSON body of the create request (properties are top level attributes)
+func (o *CreateImageOptions) body() (map[string]interface{}, error) {
+	type options CreateImageOptions
+	data, err := json.Marshal((*options)(o))
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]interface{})
+	if err = json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for k, v := range o.Properties {
+		body[k] = v
+	}
+	return body, nil
+}
+
+// CreateImage creates an image record, the returned image is in queued status
+// waiting for its data (UploadImageData or ImportImage)
+func (g *Glance) CreateImage(options *CreateImageOptions) (*Image, error) {
+	body, err := options.body()
+	if err != nil {
+		return nil, err
+	}
+	image := new(Image)
+	_, err = g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: g.prefix + "images",
+	}, body, image, []int{201})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// GetImage returns image id
+func (g *Glance) GetImage(id string) (*Image, error) {
+	image := new(Image)
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: g.prefix + "images/" + url.PathEscape(id),
+	}, nil, image, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// ListImages returns images matching options, following pages
+func (g *Glance) ListImages(options *ListOptions) (images []Image, err error) {
+	o := ListOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		var r struct {
+			Images []Image `json:"images"`
+			Next   string  `json:"next"`
+		}
+		_, err = g.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: g.prefix + "images" + o.query(),
+		}, nil, &r, []int{200})
+		if err != nil {
+			return
+		}
+		images = append(images, r.Images...)
+		if o.Marker = nextMarker(r.Next); o.Marker == "" || len(r.Images) == 0 {
+			return
+		}
+	}
+}
+
+// DeleteImage deletes image id
+func (g *Glance) DeleteImage(id string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: g.prefix + "images/" + url.PathEscape(id),
+	}, nil, nil, []int{204})
+	return err
+}
+
+// DeactivateImage deactivates image id (admin only), its data can not be downloaded anymore
+func (g *Glance) DeactivateImage(id string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/actions/deactivate",
+	}, nil, nil, []int{204})
+	return err
+}
+
+// ReactivateImage reactivates the deactivated image id (admin only)
+func (g *Glance) ReactivateImage(id string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/actions/reactivate",
+	}, nil, nil, []int{204})
+	return err
+}
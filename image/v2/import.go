@@ -0,0 +1,119 @@
+package imageV2
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Import methods
+const (
+	ImportWebDownload  = "web-download"
+	ImportGlanceDirect = "glance-direct"
+	ImportCopyImage    = "copy-image"
+)
+
+// ImportOptions represents options of an image import
+type ImportOptions struct {
+	Method string // ImportWebDownload, ImportGlanceDirect...
+	Uri    string // Data URL (web-download only)
+	// Stores to import data to (multi stores deployments)
+	Stores               []string
+	AllStores            bool
+	AllStoresMustSucceed *bool
+}
+
+// body returns the JSON body of the import request
+func (o *ImportOptions) body() map[string]interface{} {
+	method := map[string]string{"name": o.Method}
+	if o.Uri != "" {
+		method["uri"] = o.Uri
+	}
+	body := map[string]interface{}{"method": method}
+	if len(o.Stores) != 0 {
+		body["stores"] = o.Stores
+	}
+	if o.AllStores {
+		body["all_stores"] = true
+	}
+	if o.AllStoresMustSucceed != nil {
+		body["all_stores_must_succeed"] = *o.AllStoresMustSucceed
+	}
+	return body
+}
+
+// GetImportMethods returns import methods enabled on the cloud
+func (g *Glance) GetImportMethods() ([]string, error) {
+	var r struct {
+		ImportMethods struct {
+			Value []string `json:"value"`
+		} `json:"import-methods"`
+	}
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: g.prefix + "info/import",
+	}, nil, &r, []int{200})
+	return r.ImportMethods.Value, err
+}
+
+// StagedData represents data uploaded to the staging area of an image
+// It holds their checksums to verify the imported image
+type StagedData struct {
+	Id string // Image id
+	h  *hashingReader
+}
+
+// Check returns an error if checksums of the imported (active) image
+// do not match staged data
+func (s *StagedData) Check(image *Image) error {
+	return s.h.check(image)
+}
+
+// StageImageData uploads (streams) data of image id to the staging area
+// Data are then imported using the glance-direct method (ImportStagedImage),
+// use WaitForImport to wait for the image and verify it
+func (g *Glance) StageImageData(id string, data io.Reader) (*StagedData, error) {
+	h := newHashingReader(data, DefaultHashAlgo)
+	resp, err := g.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/stage",
+		Headers:   map[string]string{"Content-Type": "application/octet-stream"},
+		Payload:   h,
+	})
+	if err = resp.HandleErr(err, []int{204}); err != nil {
+		return nil, err
+	}
+	return &StagedData{id, h}, nil
+}
+
+// WaitForImport waits until the image of staged data is active and
+// verifies its checksums against staged data
+func (g *Glance) WaitForImport(ctx context.Context, staged *StagedData) (*Image, error) {
+	image, err := g.WaitForImageStatus(ctx, staged.Id, StatusActive)
+	if err != nil {
+		return image, err
+	}
+	return image, staged.Check(image)
+}
+
+// ImportImage starts the import of image id data (asynchronous),
+// use WaitForImageStatus to wait for the active status
+func (g *Glance) ImportImage(id string, options *ImportOptions) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/import",
+	}, options.body(), nil, []int{202})
+	return err
+}
+
+// ImportImageFromUrl starts the import of image id data from uri (web-download)
+func (g *Glance) ImportImageFromUrl(id, uri string) error {
+	return g.ImportImage(id, &ImportOptions{Method: ImportWebDownload, Uri: uri})
+}
+
+// ImportStagedImage starts the import of staged data of image id (glance-direct)
+func (g *Glance) ImportStagedImage(id string) error {
+	return g.ImportImage(id, &ImportOptions{Method: ImportGlanceDirect})
+}
@@ -0,0 +1,90 @@
+package imageV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Member status
+const (
+	MemberPending  = "pending"
+	MemberAccepted = "accepted"
+	MemberRejected = "rejected"
+)
+
+// A Member is a project an image is shared with (shared visibility)
+type Member struct {
+	ImageId   string              `json:"image_id"`
+	MemberId  string              `json:"member_id"` // Project id
+	Status    string              `json:"status"`
+	CreatedAt gopenstack.DateTime `json:"created_at"`
+	UpdatedAt gopenstack.DateTime `json:"updated_at"`
+	Schema    string              `json:"schema"`
+}
+
+// memberRessource returns the ressource of member memberId of image id
+func (g *Glance) memberRessource(id, memberId string) string {
+	return g.prefix + "images/" + url.PathEscape(id) + "/members/" + url.PathEscape(memberId)
+}
+
+// ListImageMembers returns members of image id
+func (g *Glance) ListImageMembers(id string) ([]Member, error) {
+	var r struct {
+		Members []Member `json:"members"`
+	}
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/members",
+	}, nil, &r, []int{200})
+	return r.Members, err
+}
+
+// GetImageMember returns member memberId of image id
+func (g *Glance) GetImageMember(id, memberId string) (*Member, error) {
+	member := new(Member)
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: g.memberRessource(id, memberId),
+	}, nil, member, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// AddImageMember shares image id with project memberId (pending until accepted by the member)
+func (g *Glance) AddImageMember(id, memberId string) (*Member, error) {
+	member := new(Member)
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/members",
+	}, map[string]string{"member": memberId}, member, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// UpdateImageMember sets the status (MemberAccepted, MemberRejected...) of member memberId of image id
+// It must be called by the member project
+func (g *Glance) UpdateImageMember(id, memberId, status string) (*Member, error) {
+	member := new(Member)
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: g.memberRessource(id, memberId),
+	}, map[string]string{"status": status}, member, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// DeleteImageMember stops sharing image id with project memberId
+func (g *Glance) DeleteImageMember(id, memberId string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: g.memberRessource(id, memberId),
+	}, nil, nil, []int{204})
+	return err
+}
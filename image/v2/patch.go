@@ -0,0 +1,77 @@
+package imageV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// PatchContentType is the content type of image updates
+const PatchContentType = "application/openstack-images-v2.1-json-patch"
+
+// A PatchOperation is a JSON-patch operation on an image attribute or property
+type PatchOperation struct {
+	Op    string      `json:"op"`    // add, replace or remove
+	Path  string      `json:"path"`  // eg /name, /tags, /os_distro
+	Value interface{} `json:"value"` // zero values are sent, not used by remove
+}
+
+// MarshalJSON encodes the operation, remove operations have no value
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+// Add returns an operation adding (or replacing) attribute
+func Add(attribute string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "add", Path: "/" + attribute, Value: value}
+}
+
+// Replace returns an operation replacing the existing attribute
+func Replace(attribute string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "replace", Path: "/" + attribute, Value: value}
+}
+
+// Remove returns an operation removing attribute (custom properties only)
+func Remove(attribute string) PatchOperation {
+	return PatchOperation{Op: "remove", Path: "/" + attribute}
+}
+
+// UpdateImage applies operations to image id and returns the updated image
+func (g *Glance) UpdateImage(id string, operations ...PatchOperation) (*Image, error) {
+	image := new(Image)
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PATCH",
+		Ressource: g.prefix + "images/" + url.PathEscape(id),
+		Headers:   map[string]string{"Content-Type": PatchContentType},
+	}, operations, image, []int{200})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// SetImageProperties adds or replaces properties of image id
+func (g *Glance) SetImageProperties(id string, properties map[string]string) (*Image, error) {
+	operations := make([]PatchOperation, 0, len(properties))
+	for k, v := range properties {
+		operations = append(operations, Add(k, v))
+	}
+	return g.UpdateImage(id, operations...)
+}
+
+// RemoveImageProperties removes properties of image id
+func (g *Glance) RemoveImageProperties(id string, properties ...string) (*Image, error) {
+	operations := make([]PatchOperation, 0, len(properties))
+	for _, p := range properties {
+		operations = append(operations, Remove(p))
+	}
+	return g.UpdateImage(id, operations...)
+}
@@ -0,0 +1,25 @@
+package imageV2
+
+import (
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// AddImageTag adds tag to image id
+func (g *Glance) AddImageTag(id, tag string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/tags/" + url.PathEscape(tag),
+	}, nil, nil, []int{204})
+	return err
+}
+
+// DeleteImageTag removes tag from image id
+func (g *Glance) DeleteImageTag(id, tag string) error {
+	_, err := g.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: g.prefix + "images/" + url.PathEscape(id) + "/tags/" + url.PathEscape(tag),
+	}, nil, nil, []int{204})
+	return err
+}
@@ -0,0 +1,54 @@
+package imageV2
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Import properties set by glance
+const (
+	propImportingToStores = "os_glance_importing_to_stores"
+	propFailedImport      = "os_glance_failed_import"
+)
+
+// WaitForImageStatus waits until image id reaches status (eg StatusActive after an import)
+// It fails if the image is killed or deleted, or if an import fails: glance then
+// leaves the image queued or uploading with os_glance_failed_import set
+func (g *Glance) WaitForImageStatus(ctx context.Context, id, status string) (*Image, error) {
+	var (
+		image     *Image
+		importing bool
+	)
+	_, err := gopenstack.WaitForStatus(ctx, func() (string, error) {
+		i, err := g.GetImage(id)
+		if err != nil {
+			return "", err
+		}
+		image = i
+		if !strings.EqualFold(i.Status, status) {
+			if err = importFailure(i, importing); err != nil {
+				return i.Status, err
+			}
+		}
+		importing = importing || i.Status == StatusImporting
+		return i.Status, nil
+	}, &gopenstack.WaitOptions{Target: []string{status}, Failure: []string{StatusKilled, StatusDeleted}})
+	return image, err
+}
+
+// importFailure returns a *StatusError if the import of image failed
+// wasImporting is true if the image was previously seen importing
+func importFailure(image *Image, wasImporting bool) error {
+	if image.Properties[propImportingToStores] != "" {
+		return nil
+	}
+	if failed := image.Properties[propFailedImport]; failed != "" {
+		return &gopenstack.StatusError{Status: image.Status, Reason: "import failed to stores " + failed}
+	}
+	if wasImporting && (image.Status == StatusQueued || image.Status == StatusUploading) {
+		return &gopenstack.StatusError{Status: image.Status, Reason: "import failed"}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package gopenstack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Microversions
+// Nova, Cinder, Manila and Ironic gate fields and behaviours behind
+// microversions ("2.53"), requested with the OpenStack-API-Version header
+// (and legacy service specific headers)
+
+// MicroversionLatest requests the latest microversion supported by the service
+const MicroversionLatest = "latest"
+
+// microversionService is the service name used in OpenStack-API-Version
+// and the legacy header (if any) of a catalog type
+type microversionService struct {
+	name         string
+	legacyHeader string
+}
+
+var microversionServices = map[string]microversionService{
+	"compute":            {"compute", "X-OpenStack-Nova-API-Version"},
+	"volume":             {"volume", ""},
+	"volumev3":           {"volume", ""},
+	"block-storage":      {"volume", ""},
+	"sharev2":            {"shared-file-system", "X-OpenStack-Manila-API-Version"},
+	"shared-file-system": {"shared-file-system", "X-OpenStack-Manila-API-Version"},
+	"baremetal":          {"baremetal", "X-OpenStack-Ironic-API-Version"},
+}
+
+// getMicroversionService returns the microversion service of the client
+func (c *Client) getMicroversionService() microversionService {
+	if s, ok := microversionServices[c.serviceType]; ok {
+		return s
+	}
+	return microversionService{name: c.serviceType}
+}
+
+// setMicroversionHeaders sets headers requesting microversion
+func (c *Client) setMicroversionHeaders(h http.Header, microversion string) {
+	s := c.getMicroversionService()
+	h.Set("OpenStack-API-Version", s.name+" "+microversion)
+	if s.legacyHeader != "" {
+		h.Set(s.legacyHeader, microversion)
+	}
+}
+
+// responseMicroversion returns the microversion of a response from its headers
+func (c *Client) responseMicroversion(h http.Header) string {
+	s := c.getMicroversionService()
+	for _, v := range h["Openstack-Api-Version"] {
+		if f := strings.Fields(v); len(f) == 2 && f[0] == s.name {
+			return f[1]
+		}
+	}
+	if s.legacyHeader != "" {
+		return h.Get(s.legacyHeader)
+	}
+	return ""
+}
+
+// APIVersion represents a version of a service API, as returned by its version document
+type APIVersion struct {
+	Id         string `json:"id"`          // eg v2.1
+	Status     string `json:"status"`      // CURRENT, SUPPORTED or DEPRECATED
+	Version    string `json:"version"`     // Max microversion (empty if microversions are not supported)
+	MinVersion string `json:"min_version"` // Min microversion
+	Links      []Link `json:"links"`
+}
+
+// versionSegment matches the version segment of an endpoint path
+var versionSegment = regexp.MustCompile(`^v\d+(\.\d+)?$`)
+
+// versionedEndpoint returns the root of the API version of endpoint
+// (eg http://nova/v2.1 for http://nova/v2.1/{project_id})
+func versionedEndpoint(endpoint string) (root, version string) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint, ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, p := range parts {
+		if versionSegment.MatchString(p) {
+			u.Path = "/" + strings.Join(parts[:i+1], "/")
+			return u.String(), p
+		}
+	}
+	return strings.TrimSuffix(endpoint, "/"), ""
+}
+
+// DiscoverVersions fetches (once) the version document of the service
+// and returns the version of the client endpoint
+func (c *Client) DiscoverVersions() (*APIVersion, error) {
+	c.mu.Lock()
+	versions := c.versions
+	c.mu.Unlock()
+	if versions != nil {
+		return versions, nil
+	}
+	root, version := versionedEndpoint(c.endpoint)
+	resp, err := c.Call(&CallOptions{
+		Method:   "GET",
+		Endpoint: root,
+	})
+	if err = resp.HandleErr(err, []int{200, 300}); err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Version  *APIVersion  `json:"version"`
+		Versions []APIVersion `json:"versions"`
+	}
+	if err = json.Unmarshal(resp.Body, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Version == nil {
+		for i, v := range doc.Versions {
+			if version != "" && (v.Id == version || strings.HasPrefix(v.Id, version+".")) {
+				doc.Version = &doc.Versions[i]
+				break
+			}
+			if version == "" && v.Status == "CURRENT" {
+				doc.Version = &doc.Versions[i]
+			}
+		}
+	}
+	if doc.Version == nil {
+		return nil, ErrVersionNotFound(c.endpoint)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// keep the first document if discovered concurrently
+	if c.versions == nil {
+		c.versions = doc.Version
+	}
+	return c.versions, nil
+}
+
+// SetMicroversion sets the microversion requested by calls of the client
+// (no negotiation, use NegotiateMicroversion to check it against the service)
+func (c *Client) SetMicroversion(microversion string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.microversion = microversion
+}
+
+// GetMicroversion returns the microversion requested by calls of the client
+func (c *Client) GetMicroversion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.microversion
+}
+
+// MicroversionAtLeast returns true if the client microversion is at least min
+func (c *Client) MicroversionAtLeast(min string) bool {
+	current := c.GetMicroversion()
+	if current == "" {
+		return false
+	}
+	if current == MicroversionLatest {
+		return true
+	}
+	cmp, err := CompareMicroversions(current, min)
+	return err == nil && cmp >= 0
+}
+
+// RequiredMicroversion returns the microversion to request for calls needing at least min:
+// empty (client one) if the client microversion is enough, min otherwise
+func (c *Client) RequiredMicroversion(min string) string {
+	if c.MicroversionAtLeast(min) {
+		return ""
+	}
+	return min
+}
+
+// NegotiateMicroversion discovers microversions supported by the service and
+// sets the client microversion to the highest supported version not above
+// wanted (MicroversionLatest for the max one). It returns the chosen microversion.
+func (c *Client) NegotiateMicroversion(wanted string) (string, error) {
+	v, err := c.DiscoverVersions()
+	if err != nil {
+		return "", err
+	}
+	if v.Version == "" {
+		return "", ErrMicroversionNotSupported(c.serviceType, wanted)
+	}
+	chosen := v.Version
+	if wanted != MicroversionLatest {
+		cmp, err := CompareMicroversions(wanted, v.Version)
+		if err != nil {
+			return "", err
+		}
+		if cmp < 0 {
+			chosen = wanted
+		}
+	}
+	if v.MinVersion != "" {
+		if cmp, err := CompareMicroversions(chosen, v.MinVersion); err != nil || cmp < 0 {
+			return "", ErrMicroversionNotSupported(c.serviceType, wanted)
+		}
+	}
+	c.SetMicroversion(chosen)
+	return chosen, nil
+}
+
+// SupportsMicroversion returns true if the service supports microversion
+func (c *Client) SupportsMicroversion(microversion string) (bool, error) {
+	v, err := c.DiscoverVersions()
+	if err != nil || v.Version == "" {
+		return false, err
+	}
+	if cmp, err := CompareMicroversions(microversion, v.Version); err != nil || cmp > 0 {
+		return false, err
+	}
+	if v.MinVersion == "" {
+		return true, nil
+	}
+	cmp, err := CompareMicroversions(microversion, v.MinVersion)
+	return cmp >= 0, err
+}
+
+// parseMicroversion parses a "major.minor" microversion
+func parseMicroversion(v string) (major, minor int, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, ErrInvalidMicroversion(v)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, ErrInvalidMicroversion(v)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, ErrInvalidMicroversion(v)
+	}
+	return
+}
+
+// CompareMicroversions returns -1, 0 or 1 if a is lower, equal or greater than b
+func CompareMicroversions(a, b string) (int, error) {
+	aMajor, aMinor, err := parseMicroversion(a)
+	if err != nil {
+		return 0, err
+	}
+	bMajor, bMinor, err := parseMicroversion(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case aMajor < bMajor || (aMajor == bMajor && aMinor < bMinor):
+		return -1, nil
+	case aMajor == bMajor && aMinor == bMinor:
+		return 0, nil
+	}
+	return 1, nil
+}
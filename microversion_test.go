@@ -0,0 +1,58 @@
+package gopenstack_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/gopenstacktest"
+)
+
+func TestNegotiateMicroversionConcurrent(t *testing.T) {
+	c := gopenstacktest.NewClient(t, "compute", "/v2.1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": {"id": "v2.1", "status": "CURRENT", "version": "2.90", "min_version": "2.1"}}`))
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.NegotiateMicroversion("2.60"); err != nil {
+				t.Error(err)
+			}
+			c.GetMicroversion()
+			c.Call(&gopenstack.CallOptions{Method: "GET", Ressource: "servers"})
+		}()
+	}
+	wg.Wait()
+	if got := c.GetMicroversion(); got != "2.60" {
+		t.Errorf("microversion %q, expected 2.60", got)
+	}
+}
+
+func TestMicroversionAtLeast(t *testing.T) {
+	c := &gopenstack.Client{}
+	for _, tc := range []struct {
+		current, min string
+		atLeast      bool
+	}{
+		{"", "2.26", false},
+		{"2.25", "2.26", false},
+		{"2.26", "2.26", true},
+		{"2.100", "2.26", true},
+		{gopenstack.MicroversionLatest, "2.26", true},
+	} {
+		c.SetMicroversion(tc.current)
+		if got := c.MicroversionAtLeast(tc.min); got != tc.atLeast {
+			t.Errorf("%q at least %q: %v", tc.current, tc.min, got)
+		}
+		expected := tc.min
+		if tc.atLeast {
+			expected = ""
+		}
+		if got := c.RequiredMicroversion(tc.min); got != expected {
+			t.Errorf("%q required for %q: %q, expected %q", tc.current, tc.min, got, expected)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A FloatingIp represents a neutron floating IP
+type FloatingIp struct {
+	Id                string              `json:"id"`
+	FloatingIpAddress string              `json:"floating_ip_address"`
+	FloatingNetworkId string              `json:"floating_network_id"`
+	FixedIpAddress    string              `json:"fixed_ip_address"` // empty if not associated
+	PortId            string              `json:"port_id"`          // empty if not associated
+	RouterId          string              `json:"router_id"`
+	Status            string              `json:"status"`
+	Description       string              `json:"description"`
+	DnsName           string              `json:"dns_name"`
+	DnsDomain         string              `json:"dns_domain"`
+	ProjectId         string              `json:"project_id"`
+	Tags              []string            `json:"tags"`
+	RevisionNumber    int                 `json:"revision_number"`
+	CreatedAt         gopenstack.DateTime `json:"created_at"`
+	UpdatedAt         gopenstack.DateTime `json:"updated_at"`
+}
+
+// FloatingIpOptions represents attributes of a floating IP to allocate
+type FloatingIpOptions struct {
+	FloatingNetworkId string `json:"floating_network_id"` // external network
+	FloatingIpAddress string `json:"floating_ip_address,omitempty"`
+	SubnetId          string `json:"subnet_id,omitempty"`
+	PortId            string `json:"port_id,omitempty"` // associate on allocation
+	FixedIpAddress    string `json:"fixed_ip_address,omitempty"`
+	Description       string `json:"description,omitempty"`
+	DnsName           string `json:"dns_name,omitempty"`
+	DnsDomain         string `json:"dns_domain,omitempty"`
+	ProjectId         string `json:"project_id,omitempty"` // admin only
+}
+
+// ListFloatingIps returns floating IPs matching options, following pages
+func (n *Neutron) ListFloatingIps(options *ListOptions) (ips []FloatingIp, err error) {
+	err = n.listPages("floatingips", options, func(body []byte) (int, error) {
+		var r struct {
+			FloatingIps []FloatingIp `json:"floatingips"`
+		}
+		err := json.Unmarshal(body, &r)
+		ips = append(ips, r.FloatingIps...)
+		return len(r.FloatingIps), err
+	})
+	return
+}
+
+// GetFloatingIp returns floating IP id
+func (n *Neutron) GetFloatingIp(id string) (*FloatingIp, error) {
+	var r struct {
+		FloatingIp FloatingIp `json:"floatingip"`
+	}
+	if err := n.get("floatingips/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.FloatingIp, nil
+}
+
+// AllocateFloatingIp allocates a floating IP
+func (n *Neutron) AllocateFloatingIp(options *FloatingIpOptions) (*FloatingIp, error) {
+	var r struct {
+		FloatingIp FloatingIp `json:"floatingip"`
+	}
+	if err := n.create("floatingips", map[string]interface{}{"floatingip": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.FloatingIp, nil
+}
+
+// AssociateFloatingIp associates floating IP id to port portId
+// fixedIp is the port address to use if it has several (optional)
+func (n *Neutron) AssociateFloatingIp(id, portId, fixedIp string) (*FloatingIp, error) {
+	ip := map[string]interface{}{"port_id": portId}
+	if fixedIp != "" {
+		ip["fixed_ip_address"] = fixedIp
+	}
+	return n.updateFloatingIp(id, ip)
+}
+
+// DisassociateFloatingIp disassociates floating IP id from its port
+func (n *Neutron) DisassociateFloatingIp(id string) (*FloatingIp, error) {
+	return n.updateFloatingIp(id, map[string]interface{}{"port_id": nil})
+}
+
+// updateFloatingIp updates floating IP id with attributes ip
+func (n *Neutron) updateFloatingIp(id string, ip map[string]interface{}) (*FloatingIp, error) {
+	var r struct {
+		FloatingIp FloatingIp `json:"floatingip"`
+	}
+	if err := n.update("floatingips/"+url.PathEscape(id), map[string]interface{}{"floatingip": ip}, &r); err != nil {
+		return nil, err
+	}
+	return &r.FloatingIp, nil
+}
+
+// ReleaseFloatingIp releases (deletes) floating IP id
+func (n *Neutron) ReleaseFloatingIp(id string) error {
+	return n.delete("floatingips/" + url.PathEscape(id))
+}
@@ -0,0 +1,100 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Network represents a neutron network
+type Network struct {
+	Id                    string              `json:"id"`
+	Name                  string              `json:"name"`
+	Description           string              `json:"description"`
+	Status                string              `json:"status"`
+	AdminStateUp          bool                `json:"admin_state_up"`
+	Shared                bool                `json:"shared"`
+	External              bool                `json:"router:external"`
+	Mtu                   int                 `json:"mtu"`
+	Subnets               []string            `json:"subnets"`
+	PortSecurityEnabled   bool                `json:"port_security_enabled"`
+	AvailabilityZones     []string            `json:"availability_zones"`
+	AvailabilityZoneHints []string            `json:"availability_zone_hints"`
+	NetworkType           string              `json:"provider:network_type"`
+	PhysicalNetwork       string              `json:"provider:physical_network"`
+	SegmentationId        int                 `json:"provider:segmentation_id"`
+	ProjectId             string              `json:"project_id"`
+	Tags                  []string            `json:"tags"`
+	RevisionNumber        int                 `json:"revision_number"`
+	CreatedAt             gopenstack.DateTime `json:"created_at"`
+	UpdatedAt             gopenstack.DateTime `json:"updated_at"`
+}
+
+// NetworkOptions represents attributes of a network to create or update
+// Empty attributes are not sent (defaults on creation, unchanged on update)
+type NetworkOptions struct {
+	Name                  string   `json:"name,omitempty"`
+	Description           string   `json:"description,omitempty"`
+	AdminStateUp          *bool    `json:"admin_state_up,omitempty"`
+	Shared                *bool    `json:"shared,omitempty"`
+	External              *bool    `json:"router:external,omitempty"`
+	Mtu                   int      `json:"mtu,omitempty"`
+	PortSecurityEnabled   *bool    `json:"port_security_enabled,omitempty"`
+	AvailabilityZoneHints []string `json:"availability_zone_hints,omitempty"` // creation only
+	NetworkType           string   `json:"provider:network_type,omitempty"`
+	PhysicalNetwork       string   `json:"provider:physical_network,omitempty"`
+	SegmentationId        int      `json:"provider:segmentation_id,omitempty"`
+	ProjectId             string   `json:"project_id,omitempty"` // creation only (admin)
+}
+
+// ListNetworks returns networks matching options, following pages
+func (n *Neutron) ListNetworks(options *ListOptions) (networks []Network, err error) {
+	err = n.listPages("networks", options, func(body []byte) (int, error) {
+		var r struct {
+			Networks []Network `json:"networks"`
+		}
+		err := json.Unmarshal(body, &r)
+		networks = append(networks, r.Networks...)
+		return len(r.Networks), err
+	})
+	return
+}
+
+// GetNetwork returns network id
+func (n *Neutron) GetNetwork(id string) (*Network, error) {
+	var r struct {
+		Network Network `json:"network"`
+	}
+	if err := n.get("networks/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.Network, nil
+}
+
+// CreateNetwork creates a network
+func (n *Neutron) CreateNetwork(options *NetworkOptions) (*Network, error) {
+	var r struct {
+		Network Network `json:"network"`
+	}
+	if err := n.create("networks", map[string]interface{}{"network": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Network, nil
+}
+
+// UpdateNetwork updates network id
+func (n *Neutron) UpdateNetwork(id string, options *NetworkOptions) (*Network, error) {
+	var r struct {
+		Network Network `json:"network"`
+	}
+	if err := n.update("networks/"+url.PathEscape(id), map[string]interface{}{"network": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Network, nil
+}
+
+// DeleteNetwork deletes network id
+func (n *Neutron) DeleteNetwork(id string) error {
+	return n.delete("networks/" + url.PathEscape(id))
+}
@@ -0,0 +1,144 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Neutron is a high-level representation of the openstack networking service (neutron v2.0)
+// The client must be created with the "network" catalog type
+type Neutron struct {
+	client *gopenstack.Client
+	prefix string // API version path, empty if the endpoint is versioned
+}
+
+// NewNeutron returns a Neutron
+func NewNeutron(client *gopenstack.Client) *Neutron {
+	n := &Neutron{client: client}
+	// neutron catalog endpoints are usually unversioned
+	if !strings.HasSuffix(strings.TrimSuffix(client.GetEndpoint(), "/"), "/v2.0") {
+		n.prefix = "v2.0/"
+	}
+	return n
+}
+
+// ListOptions represents filters and pagination of listings
+type ListOptions struct {
+	// Filters on resources attributes (eg name, network_id, device_owner...),
+	// several values of an attribute match any of them
+	Filters url.Values
+	Tags    []string // Resources having all tags
+	Fields  []string // Only return these fields
+	SortKey string
+	SortDir string // asc or desc
+	Limit   int    // Page size
+	Marker  string // Id of the last resource of the previous page
+}
+
+// query returns the query string corresponding to options
+func (o *ListOptions) query() string {
+	v := url.Values{}
+	if o == nil {
+		return ""
+	}
+	for k, values := range o.Filters {
+		for _, value := range values {
+			v.Add(k, value)
+		}
+	}
+	if len(o.Tags) != 0 {
+		v.Set("tags", strings.Join(o.Tags, ","))
+	}
+	for _, f := range o.Fields {
+		v.Add("fields", f)
+	}
+	if o.SortKey != "" {
+		v.Set("sort_key", o.SortKey)
+	}
+	if o.SortDir != "" {
+		v.Set("sort_dir", o.SortDir)
+	}
+	return gopenstack.PageQuery(v, o.Limit, o.Marker)
+}
+
+// Filter returns options filtering attribute on values
+func Filter(attribute string, values ...string) *ListOptions {
+	return &ListOptions{Filters: url.Values{attribute: values}}
+}
+
+// listPages calls page with the body of each page of resources listing
+// (URL path, eg "security-groups"), page returns the number of resources of the page
+func (n *Neutron) listPages(resources string, options *ListOptions, page func(body []byte) (int, error)) error {
+	o := ListOptions{}
+	if options != nil {
+		o = *options
+	}
+	for {
+		resp, err := n.client.CallJSON(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: n.prefix + resources + o.query(),
+		}, nil, nil, []int{200})
+		if err != nil {
+			return err
+		}
+		count, err := page(resp.Body)
+		if err != nil {
+			return err
+		}
+		var links map[string]json.RawMessage
+		if err = json.Unmarshal(resp.Body, &links); err != nil {
+			return err
+		}
+		var pageLinks []gopenstack.Link
+		// JSON keys use underscores (security_groups_links)
+		if raw, ok := links[strings.ReplaceAll(resources, "-", "_")+"_links"]; ok {
+			if err = json.Unmarshal(raw, &pageLinks); err != nil {
+				return err
+			}
+		}
+		next := gopenstack.NextMarker(pageLinks)
+		if next == "" || count == 0 {
+			return nil
+		}
+		o.Marker = next
+	}
+}
+
+// get gets resource (eg "networks/{id}") in out
+func (n *Neutron) get(resource string, out interface{}) error {
+	_, err := n.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: n.prefix + resource,
+	}, nil, out, []int{200})
+	return err
+}
+
+// create creates resource (eg "networks") with body in, the result is decoded in out
+func (n *Neutron) create(resources string, in, out interface{}) error {
+	_, err := n.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: n.prefix + resources,
+	}, in, out, []int{201})
+	return err
+}
+
+// update updates resource (eg "networks/{id}") with body in, the result is decoded in out
+func (n *Neutron) update(resource string, in, out interface{}) error {
+	_, err := n.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: n.prefix + resource,
+	}, in, out, []int{200})
+	return err
+}
+
+// delete deletes resource (eg "networks/{id}")
+func (n *Neutron) delete(resource string) error {
+	_, err := n.client.CallJSON(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: n.prefix + resource,
+	}, nil, nil, []int{204})
+	return err
+}
@@ -0,0 +1,98 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Toorop/gopenstack/gopenstacktest"
+)
+
+// newTestNeutron returns a Neutron using a network service answering with handler
+func newTestNeutron(t *testing.T, handler http.HandlerFunc) *Neutron {
+	return NewNeutron(gopenstacktest.NewClient(t, "network", "", handler))
+}
+
+func TestListSecurityGroupsPages(t *testing.T) {
+	n := newTestNeutron(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2.0/security-groups" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("marker") == "" {
+			w.Write([]byte(`{"security_groups": [{"id": "sg1"}], "security_groups_links": [{"rel": "next", "href": "http://neutron/v2.0/security-groups?marker=sg1"}]}`))
+			return
+		}
+		w.Write([]byte(`{"security_groups": [{"id": "sg2"}]}`))
+	})
+	groups, err := n.ListSecurityGroups(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, g := range groups {
+		ids = append(ids, g.Id)
+	}
+	if got := strings.Join(ids, ","); got != "sg1,sg2" {
+		t.Errorf("security groups: %s", got)
+	}
+}
+
+func TestNewNeutronPrefix(t *testing.T) {
+	for path, prefix := range map[string]string{"": "v2.0/", "/": "v2.0/", "/v2.0": "", "/v2.0/": ""} {
+		n := NewNeutron(gopenstacktest.NewClient(t, "network", path, http.NotFound))
+		if n.prefix != prefix {
+			t.Errorf("endpoint path %q: prefix %q, expected %q", path, n.prefix, prefix)
+		}
+	}
+}
+
+func TestListOptionsQuery(t *testing.T) {
+	o := &ListOptions{
+		Filters: url.Values{"device_owner": {"network:dhcp", "network:router_interface"}, "name": {"a b"}},
+		Tags:    []string{"red", "blue"},
+		Fields:  []string{"id", "name"},
+		SortKey: "name",
+		Limit:   10,
+	}
+	q, err := url.ParseQuery(strings.TrimPrefix(o.query(), "?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := url.Values{
+		"device_owner": {"network:dhcp", "network:router_interface"},
+		"name":         {"a b"},
+		"tags":         {"red,blue"},
+		"fields":       {"id", "name"},
+		"sort_key":     {"name"},
+		"limit":        {"10"},
+	}
+	if !reflect.DeepEqual(q, expected) {
+		t.Errorf("query %v, expected %v", q, expected)
+	}
+	if q := Filter("network_id", "n1", "n2").query(); q != "?network_id=n1&network_id=n2" {
+		t.Errorf("filter query: %q", q)
+	}
+}
+
+func TestSubnetOptionsBody(t *testing.T) {
+	for _, tc := range []struct {
+		options  SubnetOptions
+		expected string
+	}{
+		{SubnetOptions{Name: "s", GatewayIp: "10.0.0.1"}, `{"subnet":{"gateway_ip":"10.0.0.1","name":"s"}}`},
+		{SubnetOptions{Name: "s", GatewayIp: "10.0.0.1", NoGateway: true}, `{"subnet":{"gateway_ip":null,"name":"s"}}`},
+		{SubnetOptions{DnsNameservers: &[]string{}}, `{"subnet":{"dns_nameservers":[]}}`},
+	} {
+		body, err := tc.options.body()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, _ := json.Marshal(body); string(data) != tc.expected {
+			t.Errorf("body %s, expected %s", data, tc.expected)
+		}
+	}
+}
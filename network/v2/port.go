@@ -0,0 +1,122 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Port represents a neutron port
+type Port struct {
+	Id                  string                 `json:"id"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	NetworkId           string                 `json:"network_id"`
+	Status              string                 `json:"status"`
+	AdminStateUp        bool                   `json:"admin_state_up"`
+	MacAddress          string                 `json:"mac_address"`
+	FixedIps            []FixedIp              `json:"fixed_ips"`
+	AllowedAddressPairs []AddressPair          `json:"allowed_address_pairs"`
+	SecurityGroups      []string               `json:"security_groups"`
+	PortSecurityEnabled bool                   `json:"port_security_enabled"`
+	DeviceId            string                 `json:"device_id"`
+	DeviceOwner         string                 `json:"device_owner"`
+	DnsName             string                 `json:"dns_name"`
+	BindingHostId       string                 `json:"binding:host_id"`
+	BindingVnicType     string                 `json:"binding:vnic_type"`
+	BindingVifType      string                 `json:"binding:vif_type"`
+	BindingVifDetails   map[string]interface{} `json:"binding:vif_details"`
+	BindingProfile      map[string]interface{} `json:"binding:profile"`
+	ProjectId           string                 `json:"project_id"`
+	Tags                []string               `json:"tags"`
+	RevisionNumber      int                    `json:"revision_number"`
+	CreatedAt           gopenstack.DateTime    `json:"created_at"`
+	UpdatedAt           gopenstack.DateTime    `json:"updated_at"`
+}
+
+// A FixedIp is an IP address of a port in a subnet
+// On creation IpAddress or SubnetId may be omitted
+type FixedIp struct {
+	SubnetId  string `json:"subnet_id,omitempty"`
+	IpAddress string `json:"ip_address,omitempty"`
+}
+
+// An AddressPair is an address allowed on a port besides its fixed IPs (eg a VIP)
+type AddressPair struct {
+	IpAddress  string `json:"ip_address"` // IP or CIDR
+	MacAddress string `json:"mac_address,omitempty"`
+}
+
+// PortOptions represents attributes of a port to create or update
+// Empty attributes are not sent (defaults on creation, unchanged on update),
+// set lists to an empty list to clear them
+type PortOptions struct {
+	Name                string                 `json:"name,omitempty"`
+	Description         string                 `json:"description,omitempty"`
+	NetworkId           string                 `json:"network_id,omitempty"` // creation only
+	AdminStateUp        *bool                  `json:"admin_state_up,omitempty"`
+	MacAddress          string                 `json:"mac_address,omitempty"`
+	FixedIps            *[]FixedIp             `json:"fixed_ips,omitempty"`
+	AllowedAddressPairs *[]AddressPair         `json:"allowed_address_pairs,omitempty"`
+	SecurityGroups      *[]string              `json:"security_groups,omitempty"`
+	PortSecurityEnabled *bool                  `json:"port_security_enabled,omitempty"`
+	DeviceId            string                 `json:"device_id,omitempty"`
+	DeviceOwner         string                 `json:"device_owner,omitempty"`
+	DnsName             string                 `json:"dns_name,omitempty"`
+	BindingHostId       string                 `json:"binding:host_id,omitempty"` // admin only
+	BindingVnicType     string                 `json:"binding:vnic_type,omitempty"`
+	BindingProfile      map[string]interface{} `json:"binding:profile,omitempty"` // admin only
+	ProjectId           string                 `json:"project_id,omitempty"`      // creation only (admin)
+}
+
+// ListPorts returns ports matching options, following pages
+func (n *Neutron) ListPorts(options *ListOptions) (ports []Port, err error) {
+	err = n.listPages("ports", options, func(body []byte) (int, error) {
+		var r struct {
+			Ports []Port `json:"ports"`
+		}
+		err := json.Unmarshal(body, &r)
+		ports = append(ports, r.Ports...)
+		return len(r.Ports), err
+	})
+	return
+}
+
+// GetPort returns port id
+func (n *Neutron) GetPort(id string) (*Port, error) {
+	var r struct {
+		Port Port `json:"port"`
+	}
+	if err := n.get("ports/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.Port, nil
+}
+
+// CreatePort creates a port
+func (n *Neutron) CreatePort(options *PortOptions) (*Port, error) {
+	var r struct {
+		Port Port `json:"port"`
+	}
+	if err := n.create("ports", map[string]interface{}{"port": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Port, nil
+}
+
+// UpdatePort updates port id
+func (n *Neutron) UpdatePort(id string, options *PortOptions) (*Port, error) {
+	var r struct {
+		Port Port `json:"port"`
+	}
+	if err := n.update("ports/"+url.PathEscape(id), map[string]interface{}{"port": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Port, nil
+}
+
+// DeletePort deletes port id
+func (n *Neutron) DeletePort(id string) error {
+	return n.delete("ports/" + url.PathEscape(id))
+}
@@ -0,0 +1,170 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Router represents a neutron router
+type Router struct {
+	Id                  string              `json:"id"`
+	Name                string              `json:"name"`
+	Description         string              `json:"description"`
+	Status              string              `json:"status"`
+	AdminStateUp        bool                `json:"admin_state_up"`
+	ExternalGatewayInfo *GatewayInfo        `json:"external_gateway_info"` // nil if no gateway
+	Routes              []HostRoute         `json:"routes"`                // static routes
+	Distributed         bool                `json:"distributed"`
+	Ha                  bool                `json:"ha"`
+	AvailabilityZones   []string            `json:"availability_zones"`
+	ProjectId           string              `json:"project_id"`
+	Tags                []string            `json:"tags"`
+	RevisionNumber      int                 `json:"revision_number"`
+	CreatedAt           gopenstack.DateTime `json:"created_at"`
+	UpdatedAt           gopenstack.DateTime `json:"updated_at"`
+}
+
+// GatewayInfo is the external gateway of a router
+type GatewayInfo struct {
+	NetworkId        string    `json:"network_id"`
+	EnableSnat       *bool     `json:"enable_snat,omitempty"`
+	ExternalFixedIps []FixedIp `json:"external_fixed_ips,omitempty"`
+}
+
+// RouterOptions represents attributes of a router to create or update
+// Empty attributes are not sent (defaults on creation, unchanged on update)
+type RouterOptions struct {
+	Name                string       `json:"name,omitempty"`
+	Description         string       `json:"description,omitempty"`
+	AdminStateUp        *bool        `json:"admin_state_up,omitempty"`
+	ExternalGatewayInfo *GatewayInfo `json:"external_gateway_info,omitempty"`
+	Routes              *[]HostRoute `json:"routes,omitempty"`      // replaces all static routes
+	Distributed         *bool        `json:"distributed,omitempty"` // admin only
+	Ha                  *bool        `json:"ha,omitempty"`          // admin only
+	ProjectId           string       `json:"project_id,omitempty"`  // creation only (admin)
+}
+
+// A RouterInterface is an interface of a router in a subnet
+type RouterInterface struct {
+	Id        string   `json:"id"` // router id
+	PortId    string   `json:"port_id"`
+	SubnetId  string   `json:"subnet_id"`
+	SubnetIds []string `json:"subnet_ids"`
+	ProjectId string   `json:"project_id"`
+}
+
+// ListRouters returns routers matching options, following pages
+func (n *Neutron) ListRouters(options *ListOptions) (routers []Router, err error) {
+	err = n.listPages("routers", options, func(body []byte) (int, error) {
+		var r struct {
+			Routers []Router `json:"routers"`
+		}
+		err := json.Unmarshal(body, &r)
+		routers = append(routers, r.Routers...)
+		return len(r.Routers), err
+	})
+	return
+}
+
+// GetRouter returns router id
+func (n *Neutron) GetRouter(id string) (*Router, error) {
+	var r struct {
+		Router Router `json:"router"`
+	}
+	if err := n.get("routers/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.Router, nil
+}
+
+// CreateRouter creates a router
+func (n *Neutron) CreateRouter(options *RouterOptions) (*Router, error) {
+	var r struct {
+		Router Router `json:"router"`
+	}
+	if err := n.create("routers", map[string]interface{}{"router": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Router, nil
+}
+
+// UpdateRouter updates router id
+func (n *Neutron) UpdateRouter(id string, options *RouterOptions) (*Router, error) {
+	return n.updateRouter(id, options)
+}
+
+// SetRouterGateway sets the external gateway of router id (removes it if gateway is nil)
+func (n *Neutron) SetRouterGateway(id string, gateway *GatewayInfo) (*Router, error) {
+	var info interface{} = gateway
+	if gateway == nil {
+		info = map[string]interface{}{}
+	}
+	return n.updateRouter(id, map[string]interface{}{"external_gateway_info": info})
+}
+
+// updateRouter updates router id with attributes router
+func (n *Neutron) updateRouter(id string, router interface{}) (*Router, error) {
+	var r struct {
+		Router Router `json:"router"`
+	}
+	if err := n.update("routers/"+url.PathEscape(id), map[string]interface{}{"router": router}, &r); err != nil {
+		return nil, err
+	}
+	return &r.Router, nil
+}
+
+// DeleteRouter deletes router id (its interfaces must be removed before)
+func (n *Neutron) DeleteRouter(id string) error {
+	return n.delete("routers/" + url.PathEscape(id))
+}
+
+// AddRouterInterface adds an interface to router id, in subnet subnetId
+// (with the subnet gateway IP) or on port portId (one must be empty)
+func (n *Neutron) AddRouterInterface(id, subnetId, portId string) (*RouterInterface, error) {
+	return n.routerInterface("add_router_interface", id, subnetId, portId)
+}
+
+// RemoveRouterInterface removes the interface of router id in subnet subnetId or on port portId
+func (n *Neutron) RemoveRouterInterface(id, subnetId, portId string) (*RouterInterface, error) {
+	return n.routerInterface("remove_router_interface", id, subnetId, portId)
+}
+
+// routerInterface runs interface action on router id
+func (n *Neutron) routerInterface(action, id, subnetId, portId string) (*RouterInterface, error) {
+	body := map[string]string{}
+	if subnetId != "" {
+		body["subnet_id"] = subnetId
+	}
+	if portId != "" {
+		body["port_id"] = portId
+	}
+	var r RouterInterface
+	if err := n.update("routers/"+url.PathEscape(id)+"/"+action, body, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// AddRouterRoutes adds static routes to router id, keeping others (extraroute-atomic extension)
+func (n *Neutron) AddRouterRoutes(id string, routes []HostRoute) (*Router, error) {
+	return n.routerRoutes("add_extraroutes", id, routes)
+}
+
+// RemoveRouterRoutes removes static routes from router id (extraroute-atomic extension)
+func (n *Neutron) RemoveRouterRoutes(id string, routes []HostRoute) (*Router, error) {
+	return n.routerRoutes("remove_extraroutes", id, routes)
+}
+
+// routerRoutes runs routes action on router id
+func (n *Neutron) routerRoutes(action, id string, routes []HostRoute) (*Router, error) {
+	var r struct {
+		Router Router `json:"router"`
+	}
+	body := map[string]interface{}{"router": map[string]interface{}{"routes": routes}}
+	if err := n.update("routers/"+url.PathEscape(id)+"/"+action, body, &r); err != nil {
+		return nil, err
+	}
+	return &r.Router, nil
+}
@@ -0,0 +1,249 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Security group rules directions, ether types and protocols
+const (
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+
+	EtherTypeIPv4 = "IPv4"
+	EtherTypeIPv6 = "IPv6"
+
+	ProtocolTcp    = "tcp"
+	ProtocolUdp    = "udp"
+	ProtocolIcmp   = "icmp"
+	ProtocolIcmpV6 = "ipv6-icmp"
+)
+
+// A SecurityGroup represents a neutron security group
+type SecurityGroup struct {
+	Id             string              `json:"id"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Stateful       bool                `json:"stateful"`
+	Rules          []SecurityGroupRule `json:"security_group_rules"`
+	ProjectId      string              `json:"project_id"`
+	Tags           []string            `json:"tags"`
+	RevisionNumber int                 `json:"revision_number"`
+	CreatedAt      gopenstack.DateTime `json:"created_at"`
+	UpdatedAt      gopenstack.DateTime `json:"updated_at"`
+}
+
+// SecurityGroupOptions represents attributes of a security group to create or update
+type SecurityGroupOptions struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Stateful    *bool  `json:"stateful,omitempty"`
+	ProjectId   string `json:"project_id,omitempty"` // creation only (admin)
+}
+
+// A SecurityGroupRule represents a rule of a neutron security group
+// Port range is nil for all ports (type and code for ICMP)
+type SecurityGroupRule struct {
+	Id              string              `json:"id"`
+	SecurityGroupId string              `json:"security_group_id"`
+	Direction       string              `json:"direction"`
+	EtherType       string              `json:"ethertype"`
+	Protocol        string              `json:"protocol"` // empty for any
+	PortRangeMin    *int                `json:"port_range_min"`
+	PortRangeMax    *int                `json:"port_range_max"`
+	RemoteIpPrefix  string              `json:"remote_ip_prefix"`
+	RemoteGroupId   string              `json:"remote_group_id"`
+	Description     string              `json:"description"`
+	ProjectId       string              `json:"project_id"`
+	RevisionNumber  int                 `json:"revision_number"`
+	CreatedAt       gopenstack.DateTime `json:"created_at"`
+	UpdatedAt       gopenstack.DateTime `json:"updated_at"`
+}
+
+// SecurityGroupRuleOptions represents attributes of a security group rule to create
+type SecurityGroupRuleOptions struct {
+	SecurityGroupId string `json:"security_group_id"`
+	Direction       string `json:"direction"`           // DirectionIngress or DirectionEgress
+	EtherType       string `json:"ethertype,omitempty"` // EtherTypeIPv4 (default) or EtherTypeIPv6
+	Protocol        string `json:"protocol,omitempty"`
+	PortRangeMin    *int   `json:"port_range_min,omitempty"`
+	PortRangeMax    *int   `json:"port_range_max,omitempty"`
+	RemoteIpPrefix  string `json:"remote_ip_prefix,omitempty"`
+	RemoteGroupId   string `json:"remote_group_id,omitempty"`
+	Description     string `json:"description,omitempty"`
+}
+
+// ListSecurityGroups returns security groups matching options, following pages
+func (n *Neutron) ListSecurityGroups(options *ListOptions) (groups []SecurityGroup, err error) {
+	err = n.listPages("security-groups", options, func(body []byte) (int, error) {
+		var r struct {
+			SecurityGroups []SecurityGroup `json:"security_groups"`
+		}
+		err := json.Unmarshal(body, &r)
+		groups = append(groups, r.SecurityGroups...)
+		return len(r.SecurityGroups), err
+	})
+	return
+}
+
+// GetSecurityGroup returns security group id with its rules
+func (n *Neutron) GetSecurityGroup(id string) (*SecurityGroup, error) {
+	var r struct {
+		SecurityGroup SecurityGroup `json:"security_group"`
+	}
+	if err := n.get("security-groups/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.SecurityGroup, nil
+}
+
+// CreateSecurityGroup creates a security group (with default egress rules)
+func (n *Neutron) CreateSecurityGroup(options *SecurityGroupOptions) (*SecurityGroup, error) {
+	var r struct {
+		SecurityGroup SecurityGroup `json:"security_group"`
+	}
+	if err := n.create("security-groups", map[string]interface{}{"security_group": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.SecurityGroup, nil
+}
+
+// UpdateSecurityGroup updates security group id
+func (n *Neutron) UpdateSecurityGroup(id string, options *SecurityGroupOptions) (*SecurityGroup, error) {
+	var r struct {
+		SecurityGroup SecurityGroup `json:"security_group"`
+	}
+	if err := n.update("security-groups/"+url.PathEscape(id), map[string]interface{}{"security_group": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.SecurityGroup, nil
+}
+
+// DeleteSecurityGroup deletes security group id
+func (n *Neutron) DeleteSecurityGroup(id string) error {
+	return n.delete("security-groups/" + url.PathEscape(id))
+}
+
+// ListSecurityGroupRules returns security group rules matching options, following pages
+func (n *Neutron) ListSecurityGroupRules(options *ListOptions) (rules []SecurityGroupRule, err error) {
+	err = n.listPages("security-group-rules", options, func(body []byte) (int, error) {
+		var r struct {
+			SecurityGroupRules []SecurityGroupRule `json:"security_group_rules"`
+		}
+		err := json.Unmarshal(body, &r)
+		rules = append(rules, r.SecurityGroupRules...)
+		return len(r.SecurityGroupRules), err
+	})
+	return
+}
+
+// GetSecurityGroupRule returns security group rule id
+func (n *Neutron) GetSecurityGroupRule(id string) (*SecurityGroupRule, error) {
+	var r struct {
+		SecurityGroupRule SecurityGroupRule `json:"security_group_rule"`
+	}
+	if err := n.get("security-group-rules/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.SecurityGroupRule, nil
+}
+
+// CreateSecurityGroupRule creates a security group rule
+func (n *Neutron) CreateSecurityGroupRule(options *SecurityGroupRuleOptions) (*SecurityGroupRule, error) {
+	var r struct {
+		SecurityGroupRule SecurityGroupRule `json:"security_group_rule"`
+	}
+	if err := n.create("security-group-rules", map[string]interface{}{"security_group_rule": options}, &r); err != nil {
+		return nil, err
+	}
+	return &r.SecurityGroupRule, nil
+}
+
+// DeleteSecurityGroupRule deletes security group rule id
+func (n *Neutron) DeleteSecurityGroupRule(id string) error {
+	return n.delete("security-group-rules/" + url.PathEscape(id))
+}
+
+// OpenPort allows ingress traffic of protocol on port (all ports if port <= 0)
+// from cidr in security group groupId. It is idempotent: an existing
+// matching rule is returned instead of being created again.
+// A protocol is required if port > 0.
+func (n *Neutron) OpenPort(groupId, protocol string, port int, cidr string) (*SecurityGroupRule, error) {
+	if port > 0 && protocol == "" {
+		return nil, gopenstack.ErrPortWithoutProtocol
+	}
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	options := &SecurityGroupRuleOptions{
+		SecurityGroupId: groupId,
+		Direction:       DirectionIngress,
+		EtherType:       EtherTypeIPv4,
+		Protocol:        protocol,
+		RemoteIpPrefix:  ipNet.String(),
+	}
+	if ip.To4() == nil {
+		options.EtherType = EtherTypeIPv6
+	}
+	if port > 0 {
+		options.PortRangeMin = &port
+		options.PortRangeMax = &port
+	}
+
+	if rule, err := n.findRule(options); rule != nil || err != nil {
+		return rule, err
+	}
+	rule, err := n.CreateSecurityGroupRule(options)
+	var httpErr *gopenstack.HttpError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 409 {
+		// created concurrently
+		if rule, ferr := n.findRule(options); rule != nil || ferr != nil {
+			return rule, ferr
+		}
+	}
+	return rule, err
+}
+
+// findRule returns the rule of options.SecurityGroupId matching options (nil if none)
+func (n *Neutron) findRule(options *SecurityGroupRuleOptions) (*SecurityGroupRule, error) {
+	group, err := n.GetSecurityGroup(options.SecurityGroupId)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range group.Rules {
+		if r.Direction == options.Direction && r.EtherType == options.EtherType &&
+			r.Protocol == options.Protocol && r.RemoteGroupId == "" &&
+			samePrefix(r.RemoteIpPrefix, options.RemoteIpPrefix) &&
+			samePort(r.PortRangeMin, options.PortRangeMin) && samePort(r.PortRangeMax, options.PortRangeMax) {
+			return &group.Rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// samePrefix returns true if CIDR a and b are the same (empty is any address)
+func samePrefix(a, b string) bool {
+	normalize := func(cidr string) string {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			if ones, _ := ipNet.Mask.Size(); ones == 0 {
+				return ""
+			}
+			return ipNet.String()
+		}
+		return cidr
+	}
+	return normalize(a) == normalize(b)
+}
+
+// samePort returns true if ports a and b are the same
+func samePort(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,102 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// fakeSecurityGroup serves security group sg1 with rules
+// Created rules are added to the group, after a 409 if conflict is set
+type fakeSecurityGroup struct {
+	mu       sync.Mutex
+	rules    []SecurityGroupRule
+	conflict bool
+	creates  int
+	requests int
+}
+
+func (f *fakeSecurityGroup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/v2.0/security-groups/sg1":
+		json.NewEncoder(w).Encode(map[string]interface{}{"security_group": SecurityGroup{Id: "sg1", Rules: f.rules}})
+	case r.Method == "POST" && r.URL.Path == "/v2.0/security-group-rules":
+		f.creates++
+		var body struct {
+			Rule SecurityGroupRule `json:"security_group_rule"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		body.Rule.Id = "created"
+		f.rules = append(f.rules, body.Rule)
+		if f.conflict {
+			http.Error(w, "Security group rule already exists", http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"security_group_rule": body.Rule})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestOpenPort(t *testing.T) {
+	port := 22
+	existing := []SecurityGroupRule{
+		{Id: "ssh-any", Direction: DirectionIngress, EtherType: EtherTypeIPv4, Protocol: ProtocolTcp, PortRangeMin: &port, PortRangeMax: &port},
+		{Id: "ssh-v6", Direction: DirectionIngress, EtherType: EtherTypeIPv6, Protocol: ProtocolTcp, PortRangeMin: &port, PortRangeMax: &port, RemoteIpPrefix: "2001:db8::/32"},
+	}
+	for _, tc := range []struct {
+		name     string
+		protocol string
+		port     int
+		cidr     string
+		conflict bool
+		id       string // expected rule
+		created  bool
+	}{
+		{"existing rule", ProtocolTcp, 22, "2001:db8::/32", false, "ssh-v6", false},
+		{"any address is an empty prefix", ProtocolTcp, 22, "0.0.0.0/0", false, "ssh-any", false},
+		{"other port", ProtocolTcp, 443, "0.0.0.0/0", false, "created", true},
+		{"ipv6 cidr", ProtocolTcp, 22, "::/0", false, "created", true},
+		{"created concurrently", ProtocolUdp, 53, "10.0.0.0/8", true, "created", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeSecurityGroup{rules: append([]SecurityGroupRule(nil), existing...), conflict: tc.conflict}
+			n := newTestNeutron(t, f.ServeHTTP)
+			rule, err := n.OpenPort("sg1", tc.protocol, tc.port, tc.cidr)
+			if err != nil || rule.Id != tc.id {
+				t.Fatalf("OpenPort: %+v, %v", rule, err)
+			}
+			if created := f.creates != 0; created != tc.created {
+				t.Errorf("%d rules created", f.creates)
+			}
+			if tc.created {
+				r := f.rules[len(f.rules)-1]
+				etherType := EtherTypeIPv4
+				if tc.cidr == "::/0" {
+					etherType = EtherTypeIPv6
+				}
+				if r.EtherType != etherType || r.Protocol != tc.protocol || *r.PortRangeMin != tc.port || r.RemoteIpPrefix != tc.cidr {
+					t.Errorf("created rule: %+v", r)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenPortWithoutProtocol(t *testing.T) {
+	f := &fakeSecurityGroup{}
+	n := newTestNeutron(t, f.ServeHTTP)
+	if _, err := n.OpenPort("sg1", "", 22, "0.0.0.0/0"); err != gopenstack.ErrPortWithoutProtocol {
+		t.Errorf("OpenPort without protocol: %v", err)
+	}
+	if f.requests != 0 {
+		t.Errorf("%d requests sent to neutron", f.requests)
+	}
+}
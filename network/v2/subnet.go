@@ -0,0 +1,142 @@
+package networkV2
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// A Subnet represents a neutron subnet
+type Subnet struct {
+	Id              string              `json:"id"`
+	Name            string              `json:"name"`
+	Description     string              `json:"description"`
+	NetworkId       string              `json:"network_id"`
+	IpVersion       int                 `json:"ip_version"`
+	Cidr            string              `json:"cidr"`
+	GatewayIp       string              `json:"gateway_ip"` // empty if no gateway
+	EnableDhcp      bool                `json:"enable_dhcp"`
+	DnsNameservers  []string            `json:"dns_nameservers"`
+	AllocationPools []AllocationPool    `json:"allocation_pools"`
+	HostRoutes      []HostRoute         `json:"host_routes"`
+	Ipv6AddressMode string              `json:"ipv6_address_mode"`
+	Ipv6RaMode      string              `json:"ipv6_ra_mode"`
+	SubnetpoolId    string              `json:"subnetpool_id"`
+	SegmentId       string              `json:"segment_id"`
+	ServiceTypes    []string            `json:"service_types"`
+	ProjectId       string              `json:"project_id"`
+	Tags            []string            `json:"tags"`
+	RevisionNumber  int                 `json:"revision_number"`
+	CreatedAt       gopenstack.DateTime `json:"created_at"`
+	UpdatedAt       gopenstack.DateTime `json:"updated_at"`
+}
+
+// An AllocationPool is a range of IP addresses allocated from a subnet
+type AllocationPool struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// A HostRoute is a route pushed to hosts of a subnet (by DHCP)
+type HostRoute struct {
+	Destination string `json:"destination"`
+	Nexthop     string `json:"nexthop"`
+}
+
+// SubnetOptions represents attributes of a subnet to create or update
+// Empty attributes are not sent (defaults on creation, unchanged on update)
+type SubnetOptions struct {
+	Name            string            `json:"name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	NetworkId       string            `json:"network_id,omitempty"` // creation only
+	IpVersion       int               `json:"ip_version,omitempty"` // creation only (4 or 6)
+	Cidr            string            `json:"cidr,omitempty"`       // creation only
+	GatewayIp       string            `json:"gateway_ip,omitempty"`
+	EnableDhcp      *bool             `json:"enable_dhcp,omitempty"`
+	DnsNameservers  *[]string         `json:"dns_nameservers,omitempty"`
+	AllocationPools *[]AllocationPool `json:"allocation_pools,omitempty"`
+	HostRoutes      *[]HostRoute      `json:"host_routes,omitempty"`
+	Ipv6AddressMode string            `json:"ipv6_address_mode,omitempty"` // creation only
+	Ipv6RaMode      string            `json:"ipv6_ra_mode,omitempty"`      // creation only
+	SubnetpoolId    string            `json:"subnetpool_id,omitempty"`     // creation only
+	ProjectId       string            `json:"project_id,omitempty"`        // creation only (admin)
+
+	// NoGateway disables the gateway (GatewayIp is ignored)
+	NoGateway bool `json:"-"`
+}
+
+// body returns the request body of options
+func (o *SubnetOptions) body() (map[string]interface{}, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	subnet := map[string]interface{}{}
+	if err = json.Unmarshal(data, &subnet); err != nil {
+		return nil, err
+	}
+	if o.NoGateway {
+		subnet["gateway_ip"] = nil
+	}
+	return map[string]interface{}{"subnet": subnet}, nil
+}
+
+// ListSubnets returns subnets matching options, following pages
+func (n *Neutron) ListSubnets(options *ListOptions) (subnets []Subnet, err error) {
+	err = n.listPages("subnets", options, func(body []byte) (int, error) {
+		var r struct {
+			Subnets []Subnet `json:"subnets"`
+		}
+		err := json.Unmarshal(body, &r)
+		subnets = append(subnets, r.Subnets...)
+		return len(r.Subnets), err
+	})
+	return
+}
+
+// GetSubnet returns subnet id
+func (n *Neutron) GetSubnet(id string) (*Subnet, error) {
+	var r struct {
+		Subnet Subnet `json:"subnet"`
+	}
+	if err := n.get("subnets/"+url.PathEscape(id), &r); err != nil {
+		return nil, err
+	}
+	return &r.Subnet, nil
+}
+
+// CreateSubnet creates a subnet
+func (n *Neutron) CreateSubnet(options *SubnetOptions) (*Subnet, error) {
+	body, err := options.body()
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Subnet Subnet `json:"subnet"`
+	}
+	if err = n.create("subnets", body, &r); err != nil {
+		return nil, err
+	}
+	return &r.Subnet, nil
+}
+
+// UpdateSubnet updates subnet id
+func (n *Neutron) UpdateSubnet(id string, options *SubnetOptions) (*Subnet, error) {
+	body, err := options.body()
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Subnet Subnet `json:"subnet"`
+	}
+	if err = n.update("subnets/"+url.PathEscape(id), body, &r); err != nil {
+		return nil, err
+	}
+	return &r.Subnet, nil
+}
+
+// DeleteSubnet deletes subnet id
+func (n *Neutron) DeleteSubnet(id string) error {
+	return n.delete("subnets/" + url.PathEscape(id))
+}
@@ -0,0 +1,127 @@
+package objectStorageV1
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/dsnet/compress/bzip2"
+)
+
+// Archive formats supported by the extract-archive middleware
+const (
+	ArchiveTar    = "tar"
+	ArchiveTarGz  = "tar.gz"
+	ArchiveTarBz2 = "tar.bz2"
+)
+
+// PutArchive recursively uploads files under srcPath to destPath as a single
+// tar stream (format: tar, tar.gz or tar.bz2) expanded by the cluster.
+// If extract-archive is not available on the cluster, it falls back to Put.
+func (s *Swift) PutArchive(srcPath, destPath, format string) (result BulkResult, err error) {
+	switch format {
+	case ArchiveTar, ArchiveTarGz, ArchiveTarBz2:
+	default:
+		err = gopenstack.ErrUnsupportedArchiveFormat(format)
+		return
+	}
+	srcPath, err = filepath.Abs(filepath.Clean(srcPath))
+	if err != nil {
+		return
+	}
+	if _, err = os.Stat(srcPath); err != nil {
+		err = gopenstack.ErrPathNotFound(srcPath)
+		return
+	}
+	if strings.HasSuffix(destPath, "/") {
+		destPath = destPath[:len(destPath)-1]
+	}
+	// we must have a container specified
+	if destPath == "" || destPath == "/" {
+		err = gopenstack.ErrNoContainerSpecified
+		return
+	}
+
+	if s.knownCapabilities().BulkUpload == nil {
+		err = s.Put(srcPath, destPath)
+		return
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeArchive(pw, srcPath, format))
+	}()
+	defer pr.Close()
+
+	headers := make(map[string]string)
+	headers["Accept"] = "application/json"
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: escapePath(destPath) + "?extract-archive=" + format,
+		Payload:   pr,
+		Headers:   headers,
+	})
+	if err = resp.HandleErr(err, []int{200, 201}); err != nil {
+		return
+	}
+	if err = json.Unmarshal(resp.Body, &result); err != nil {
+		return
+	}
+	err = result.Err()
+	return
+}
+
+// writeArchive writes files under srcPath to w as a (compressed) tar stream
+// Entries are prefixed by the base name of srcPath (as Put does)
+func writeArchive(w io.Writer, srcPath, format string) (err error) {
+	var cw io.WriteCloser
+	switch format {
+	case ArchiveTarGz:
+		cw = gzip.NewWriter(w)
+	case ArchiveTarBz2:
+		if cw, err = bzip2.NewWriter(w, nil); err != nil {
+			return
+		}
+	}
+	tw := tar.NewWriter(w)
+	if cw != nil {
+		tw = tar.NewWriter(cw)
+	}
+	base := filepath.Base(srcPath)
+
+	err = filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(base, path[len(srcPath):]))
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return
+	}
+	if err = tw.Close(); err != nil {
+		return
+	}
+	if cw != nil {
+		err = cw.Close()
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package objectStorageV1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// defaultBulkDeleteMaxPaths is the default number of paths accepted by a bulk-delete request
+const defaultBulkDeleteMaxPaths = 10000
+
+// BulkResult represents the response of the swift bulk middleware
+type BulkResult struct {
+	NumberDeleted      int        `json:"Number Deleted"`       // Number of deleted objects
+	NumberNotFound     int        `json:"Number Not Found"`     // Number of objects not found
+	NumberFilesCreated int        `json:"Number Files Created"` // Number of objects created (extract-archive)
+	ResponseStatus     string     `json:"Response Status"`      // Global status (eg "200 OK")
+	ResponseBody       string     `json:"Response Body"`        // Global message
+	Errors             [][]string `json:"Errors"`               // Per item errors: [path, status]
+}
+
+// Err returns an error if the bulk operation reports failures
+func (r *BulkResult) Err() error {
+	if len(r.Errors) == 0 && (r.ResponseStatus == "" || strings.HasPrefix(r.ResponseStatus, "2")) {
+		return nil
+	}
+	return gopenstack.ErrBulkFailed(r.ResponseStatus, r.Errors)
+}
+
+// add merges r2 into r
+func (r *BulkResult) add(r2 BulkResult) {
+	r.NumberDeleted += r2.NumberDeleted
+	r.NumberNotFound += r2.NumberNotFound
+	r.NumberFilesCreated += r2.NumberFilesCreated
+	r.Errors = append(r.Errors, r2.Errors...)
+	if r.ResponseStatus == "" || strings.HasPrefix(r.ResponseStatus, "2") {
+		r.ResponseStatus = r2.ResponseStatus
+		r.ResponseBody = r2.ResponseBody
+	}
+}
+
+// getBulkDeleteMaxPaths returns the max number of paths per bulk-delete request
+// or 0 if the bulk middleware is not available
+func (s *Swift) getBulkDeleteMaxPaths() int {
+	bd := s.knownCapabilities().BulkDelete
+	if bd == nil {
+		return 0
+	}
+	if bd.MaxDeletesPerRequest == 0 {
+		return defaultBulkDeleteMaxPaths
+	}
+	return bd.MaxDeletesPerRequest
+}
+
+// BulkDelete removes objects (or empty containers) using the bulk middleware
+// paths are in the form /container/object
+func (s *Swift) BulkDelete(paths []string) (result BulkResult, err error) {
+	max := s.getBulkDeleteMaxPaths()
+	if max == 0 {
+		err = gopenstack.ErrBulkDeleteNotAvailable
+		return
+	}
+	return result, s.bulkDelete(paths, max, &result)
+}
+
+// bulkDelete sends paths by chunks of max to the bulk-delete middleware
+func (s *Swift) bulkDelete(paths []string, max int, result *BulkResult) error {
+	for start := 0; start < len(paths); start += max {
+		end := start + max
+		if end > len(paths) {
+			end = len(paths)
+		}
+		body := new(bytes.Buffer)
+		for _, p := range paths[start:end] {
+			body.WriteString(escapeBulkPath(p) + "\n")
+		}
+		headers := make(map[string]string)
+		headers["Content-Type"] = "text/plain"
+		headers["Accept"] = "application/json"
+		resp, err := s.client.Call(&gopenstack.CallOptions{
+			Method:    "POST",
+			Ressource: "?bulk-delete",
+			Payload:   body,
+			Headers:   headers,
+		})
+		if err = resp.HandleErr(err, []int{200}); err != nil {
+			return err
+		}
+		var r BulkResult
+		if err = json.Unmarshal(resp.Body, &r); err != nil {
+			return err
+		}
+		result.add(r)
+	}
+	return result.Err()
+}
+
+// escapeBulkPath returns path as expected by the bulk middleware (/container/object)
+func escapeBulkPath(path string) string {
+	p := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for k, v := range p {
+		p[k] = url.PathEscape(v)
+	}
+	return "/" + strings.Join(p, "/")
+}
@@ -0,0 +1,170 @@
+package objectStorageV1
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Capabilities represents the cluster capabilities as returned by /info
+// Middleware not enabled on the cluster are nil
+type Capabilities struct {
+	Swift            SwiftCapabilities            `json:"swift"`
+	Slo              *SloCapabilities             `json:"slo"`
+	BulkDelete       *BulkDeleteCapabilities      `json:"bulk_delete"`
+	BulkUpload       *BulkUploadCapabilities      `json:"bulk_upload"`
+	TempURL          *TempURLCapabilities         `json:"tempurl"`
+	VersionedWrites  *VersionedWritesCapabilities `json:"versioned_writes"`
+	ObjectVersioning *struct{}                    `json:"object_versioning"`
+	Symlink          *SymlinkCapabilities         `json:"symlink"`
+}
+
+// SwiftCapabilities represents swift core limits
+type SwiftCapabilities struct {
+	Version                string          `json:"version"`
+	MaxFileSize            int64           `json:"max_file_size"`
+	MaxMetaNameLength      int             `json:"max_meta_name_length"`
+	MaxMetaValueLength     int             `json:"max_meta_value_length"`
+	MaxMetaCount           int             `json:"max_meta_count"`
+	MaxMetaOverallSize     int             `json:"max_meta_overall_size"`
+	MaxHeaderSize          int             `json:"max_header_size"`
+	MaxObjectNameLength    int             `json:"max_object_name_length"`
+	MaxAccountNameLength   int             `json:"max_account_name_length"`
+	MaxContainerNameLength int             `json:"max_container_name_length"`
+	ContainerListingLimit  int             `json:"container_listing_limit"`
+	AccountListingLimit    int             `json:"account_listing_limit"`
+	ExtraHeaderCount       int             `json:"extra_header_count"`
+	StrictCorsMode         bool            `json:"strict_cors_mode"`
+	AccountAutocreate      bool            `json:"account_autocreate"`
+	ValidApiVersions       []string        `json:"valid_api_versions"`
+	Policies               []StoragePolicy `json:"policies"`
+}
+
+// StoragePolicy represents a storage policy available on the cluster
+type StoragePolicy struct {
+	Name    string `json:"name"`
+	Aliases string `json:"aliases"`
+	Default bool   `json:"default"`
+}
+
+// SloCapabilities represents static large objects limits
+type SloCapabilities struct {
+	MaxManifestSegments int   `json:"max_manifest_segments"`
+	MaxManifestSize     int64 `json:"max_manifest_size"`
+	MinSegmentSize      int64 `json:"min_segment_size"`
+	YieldFrequency      int   `json:"yield_frequency"`
+}
+
+// BulkDeleteCapabilities represents bulk-delete middleware limits
+type BulkDeleteCapabilities struct {
+	MaxDeletesPerRequest int `json:"max_deletes_per_request"`
+	MaxFailedDeletes     int `json:"max_failed_deletes"`
+}
+
+// BulkUploadCapabilities represents extract-archive middleware limits
+type BulkUploadCapabilities struct {
+	MaxContainersPerExtraction int `json:"max_containers_per_extraction"`
+	MaxFailedExtractions       int `json:"max_failed_extractions"`
+}
+
+// TempURLCapabilities represents tempurl middleware configuration
+type TempURLCapabilities struct {
+	Methods               []string `json:"methods"`
+	AllowedDigests        []string `json:"allowed_digests"`
+	IncomingRemoveHeaders []string `json:"incoming_remove_headers"`
+	IncomingAllowHeaders  []string `json:"incoming_allow_headers"`
+	OutgoingRemoveHeaders []string `json:"outgoing_remove_headers"`
+	OutgoingAllowHeaders  []string `json:"outgoing_allow_headers"`
+}
+
+// VersionedWritesCapabilities represents versioned_writes middleware configuration
+type VersionedWritesCapabilities struct {
+	AllowedFlags []string `json:"allowed_flags"`
+}
+
+// SymlinkCapabilities represents symlink middleware configuration
+type SymlinkCapabilities struct {
+	SymlinkLoopLimit int  `json:"symlink_loop_limit"`
+	StaticLinks      bool `json:"static_links"`
+}
+
+// Capabilities returns the cluster capabilities fetched from /info
+// They are cached once fetched, a failed fetch is retried by the next call
+func (s *Swift) Capabilities() (*Capabilities, error) {
+	s.capabilitiesMu.Lock()
+	defer s.capabilitiesMu.Unlock()
+	if s.capabilities != nil {
+		return s.capabilities, nil
+	}
+	c, err := s.fetchCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	s.capabilities = c
+	return c, nil
+}
+
+// fetchCapabilities gets the cluster capabilities from /info
+func (s *Swift) fetchCapabilities() (*Capabilities, error) {
+	u, err := url.Parse(s.client.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	// endpoint is like https://host/v1/AUTH_xxx, info is at https://host/info
+	if i := strings.Index(u.Path, "/v1"); i != -1 {
+		u.Path = u.Path[:i]
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: "info",
+		Endpoint:  strings.TrimSuffix(u.String(), "/"),
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return nil, err
+	}
+	c := new(Capabilities)
+	if err = json.Unmarshal(resp.Body, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// getCapabilities returns the cluster capabilities or, if /info can not be
+// fetched, an empty Capabilities and a *gopenstack.CapabilitiesError.
+// Every feature depending on a middleware checks it through getCapabilities:
+// with unknown capabilities, limits are not checked, bulk operations fall back
+// to one request per object, symlinks and temp URLs are attempted and
+// versioning returns the error.
+func (s *Swift) getCapabilities() (*Capabilities, error) {
+	c, err := s.Capabilities()
+	if err != nil {
+		return &Capabilities{}, gopenstack.ErrCapabilities(err)
+	}
+	return c, nil
+}
+
+// knownCapabilities returns the cluster capabilities, empty if unknown
+func (s *Swift) knownCapabilities() *Capabilities {
+	c, _ := s.getCapabilities()
+	return c
+}
+
+// checkObjectName returns an error if name is too long for the cluster
+func (s *Swift) checkObjectName(name string) error {
+	max := s.knownCapabilities().Swift.MaxObjectNameLength
+	if max > 0 && len(name) > max {
+		return gopenstack.ErrNameTooLong(name, max)
+	}
+	return nil
+}
+
+// checkObjectSize returns an error if size exceeds the cluster max object size
+func (s *Swift) checkObjectSize(size int64) error {
+	max := s.knownCapabilities().Swift.MaxFileSize
+	if max > 0 && size > max {
+		return gopenstack.ErrObjectTooLarge(size, max)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package objectStorageV1_test
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1/swifttest"
+)
+
+// infoRequests returns the number of /info requests received by srv
+func infoRequests(srv *swifttest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "/info" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCapabilitiesRetry(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	srv.AddFault(swifttest.Fault{Path: "/info", StatusCode: 503, Times: 1})
+
+	if _, err := s.Capabilities(); !isHttpError(err, 503) {
+		t.Fatalf("Capabilities with a failing /info: %v", err)
+	}
+	// the failure is not cached
+	c, err := s.Capabilities()
+	if err != nil || c.BulkDelete == nil {
+		t.Fatalf("Capabilities after a transient failure: %+v, %v", c, err)
+	}
+	// the success is
+	srv.ResetRequests()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Capabilities(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := infoRequests(srv); n != 0 {
+		t.Errorf("%d /info requests once capabilities are known", n)
+	}
+}
+
+func TestCapabilitiesUnknown(t *testing.T) {
+	// eg a cluster with expose_info = false
+	srv, s, _ := newTestSwift(t)
+	srv.AddFault(swifttest.Fault{Path: "/info", StatusCode: 404})
+	putObjects(t, s, map[string]string{"o": "content"})
+
+	// symlinks and temp URLs are attempted
+	if err := s.AddSymlink("/c/link", "/c/o"); err != nil {
+		t.Errorf("AddSymlink: %v", err)
+	}
+	if err := s.AddStaticSymlink("/c/static", "/c/o"); err != nil {
+		t.Errorf("AddStaticSymlink: %v", err)
+	}
+	tempURL, err := s.TempURL("GET", "/c/o", time.Hour, "key")
+	if err != nil {
+		t.Fatalf("TempURL: %v", err)
+	}
+	u, err := url.Parse(tempURL)
+	if err != nil || len(u.Query().Get("temp_url_sig")) != 64 {
+		t.Errorf("TempURL %s is not signed with sha256 (%v)", tempURL, err)
+	}
+
+	// versioning reports the real error
+	err = s.SetVersionsEnabled("c", true)
+	var capErr *gopenstack.CapabilitiesError
+	if !errors.As(err, &capErr) || !isHttpError(err, 404) {
+		t.Errorf("SetVersionsEnabled: %v", err)
+	}
+	if err = s.SetVersionsLocation("c", "archive", false); !errors.As(err, &capErr) {
+		t.Errorf("SetVersionsLocation: %v", err)
+	}
+
+	// bulk operations fall back to one request per object
+	if err = s.DeletePath("/c"); err != nil {
+		t.Errorf("DeletePath: %v", err)
+	}
+	if n := infoRequests(srv); n < 2 {
+		t.Errorf("/info fetched %d times, failures must be retried", n)
+	}
+}
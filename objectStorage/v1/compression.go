@@ -0,0 +1,156 @@
+package objectStorageV1
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression algorithms
+const (
+	CompressGzip = "gzip"
+	CompressZstd = "zstd"
+)
+
+// Compression metadata headers
+// X-Object-Meta-Compression is always set, Content-Encoding and
+// X-Object-Meta-Original-* only if the object is not encrypted
+// (stored bytes are not compressed bytes, plain content is kept secret)
+const (
+	hdrCompression   = "X-Object-Meta-Compression"
+	hdrOriginalSize  = "X-Object-Meta-Original-Size"
+	hdrOriginalMd5   = "X-Object-Meta-Original-Md5"
+	hdrContentEncode = "Content-Encoding"
+)
+
+// DefaultCompressionExclude lists already compressed media which are not
+// compressed when Compression.Exclude is nil
+var DefaultCompressionExclude = []string{
+	".gz", ".tgz", ".zst", ".bz2", ".xz", ".lz4", ".zip", ".7z", ".rar",
+	".jpg", ".jpeg", ".png", ".gif", ".webp", ".mp3", ".mp4", ".mkv", ".avi", ".mov",
+	"image/*", "video/*", "audio/*", "application/zip", "application/gzip", "application/zstd",
+}
+
+// Compression represents upload compression options
+// Rules are extensions (".log") or content types ("text/plain", "text/*")
+type Compression struct {
+	Algorithm string   // CompressGzip or CompressZstd
+	Include   []string // Files to compress, all if empty
+	Exclude   []string // Files never compressed, DefaultCompressionExclude if nil
+}
+
+// match returns true if file name (of content type contentType) must be compressed
+func (c *Compression) match(name, contentType string) bool {
+	if c == nil {
+		return false
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	if contentType == "" {
+		contentType = mime.TypeByExtension(ext)
+	}
+	if i := strings.Index(contentType, ";"); i != -1 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(strings.ToLower(contentType))
+
+	exclude := c.Exclude
+	if exclude == nil {
+		exclude = DefaultCompressionExclude
+	}
+	if matchRules(exclude, ext, contentType) {
+		return false
+	}
+	return len(c.Include) == 0 || matchRules(c.Include, ext, contentType)
+}
+
+// matchRules returns true if ext or contentType matches one of rules
+func matchRules(rules []string, ext, contentType string) bool {
+	for _, rule := range rules {
+		rule = strings.ToLower(rule)
+		switch {
+		case strings.HasPrefix(rule, "."):
+			if rule == ext {
+				return true
+			}
+		case strings.HasSuffix(rule, "/*"):
+			if contentType != "" && strings.HasPrefix(contentType, rule[:len(rule)-1]) {
+				return true
+			}
+		case rule == contentType:
+			return true
+		}
+	}
+	return false
+}
+
+// newCompressReader returns a reader of src content compressed with algorithm
+// It must be closed to release the compressing goroutine
+func newCompressReader(algorithm string, src io.Reader) (io.ReadCloser, error) {
+	var w io.WriteCloser
+	pr, pw := io.Pipe()
+	switch algorithm {
+	case CompressGzip:
+		w = gzip.NewWriter(pw)
+	case CompressZstd:
+		zw, err := zstd.NewWriter(pw)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+	default:
+		return nil, gopenstack.ErrUnsupportedCompression(algorithm)
+	}
+	go func() {
+		_, err := io.Copy(w, src)
+		// w is closed even on error to release its resources
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// newDecompressReader returns a reader of src content decompressed with algorithm
+func newDecompressReader(algorithm string, src io.Reader) (io.ReadCloser, error) {
+	switch algorithm {
+	case CompressGzip:
+		return gzip.NewReader(src)
+	case CompressZstd:
+		zr, err := zstd.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return nil, gopenstack.ErrUnsupportedCompression(algorithm)
+}
+
+// compressionOf returns the compression algorithm of an object from its headers
+func compressionOf(headers http.Header) string {
+	if c := headers.Get(hdrCompression); c != "" {
+		return c
+	}
+	return headers.Get(hdrContentEncode)
+}
+
+// multiReadCloser reads from Reader and closes all closers
+type multiReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *multiReadCloser) Close() (err error) {
+	for _, c := range r.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return
+}
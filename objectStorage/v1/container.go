@@ -1,9 +1,62 @@
 package objectStorageV1
 
-// container represents a object container
-type container struct {
-	Count   uint     `json:"count"` // The number of objects in the container.
-	Name    string   `json:"name"`  // The name of the container.
-	Bytes   uint     `json:"bytes"` // The total number of bytes that are stored in Object Storage for the account.
-	Objects []object // Objects in container
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Container represents a object container
+type Container struct {
+	Count         uint64                `json:"count"`          // The number of objects in the container.
+	Name          string                `json:"name"`           // The name of the container.
+	Bytes         uint64                `json:"bytes"`          // The total number of bytes that are stored in the container.
+	LastModified  gopenstack.DateTimeOs `json:"last_modified"`  // The date and time when the container was last modified
+	StoragePolicy string                `json:"storage_policy"` // The storage policy of the container
+	Metadata      map[string]string     `json:"-"`              // Container metadata (X-Container-Meta-*), HEAD only
+	Objects       []Object              `json:"-"`              // Objects in container
+}
+
+// containerFromHeaders returns a Container populated from HEAD response headers
+func containerFromHeaders(name string, headers http.Header) (c Container) {
+	c.Name = name
+	c.Count, _ = strconv.ParseUint(headers.Get("X-Container-Object-Count"), 10, 64)
+	c.Bytes, _ = strconv.ParseUint(headers.Get("X-Container-Bytes-Used"), 10, 64)
+	c.StoragePolicy = headers.Get("X-Storage-Policy")
+	if lm, err := time.Parse(time.RFC1123, headers.Get("Last-Modified")); err == nil {
+		c.LastModified.Time = lm
+	}
+	c.Metadata = metadataFromHeaders(headers, "X-Container-Meta-")
+	return
+}
+
+// metadataFromHeaders returns headers starting with prefix (prefix removed)
+func metadataFromHeaders(headers http.Header, prefix string) map[string]string {
+	metadata := make(map[string]string)
+	for k, v := range headers {
+		if strings.HasPrefix(k, prefix) && len(v) > 0 {
+			metadata[k[len(prefix):]] = v[0]
+		}
+	}
+	return metadata
+}
+
+// HeadContainer returns container informations
+func (s *Swift) HeadContainer(name string) (c Container, err error) {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: url.QueryEscape(name),
+	})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return
+	}
+	if resp.StatusCode == 404 {
+		err = gopenstack.ErrContainerNotFound
+		return
+	}
+	return containerFromHeaders(name, resp.Headers), nil
 }
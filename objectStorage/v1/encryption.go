@@ -0,0 +1,299 @@
+package objectStorageV1
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Client side encryption
+// Content is encrypted with a random AES-256 data key per object, by frames of
+// cryptoChunkSize bytes (AES-GCM, frame index and final flag authenticated),
+// so large objects can be streamed. The data key, wrapped by a KeyProvider,
+// and encryption parameters are stored in X-Object-Meta-Crypto-* metadata.
+// The plain content md5 is only stored as an HMAC keyed by the data key, so
+// key holders can detect unchanged files without leaking it in clear metadata.
+
+// Encryption parameters
+const (
+	cryptoVersion   = "1"
+	cryptoAlg       = "AES-256-GCM"
+	cryptoChunkSize = 64 * 1024
+	cryptoTagSize   = 16
+
+	// cryptoMaxChunkSize bounds the chunk size read from metadata (buffers are sized with it)
+	cryptoMaxChunkSize = 16 * 1024 * 1024
+)
+
+// Encryption metadata headers
+const (
+	hdrCryptoVersion   = "X-Object-Meta-Crypto-Version"
+	hdrCryptoAlg       = "X-Object-Meta-Crypto-Alg"
+	hdrCryptoChunkSize = "X-Object-Meta-Crypto-Chunk-Size"
+	hdrCryptoNonce     = "X-Object-Meta-Crypto-Nonce"
+	hdrCryptoKeyId     = "X-Object-Meta-Crypto-Key-Id"
+	hdrCryptoKey       = "X-Object-Meta-Crypto-Key"
+	hdrCryptoPlainMac  = "X-Object-Meta-Crypto-Plain-Hmac"
+)
+
+// A KeyProvider wraps and unwraps objects data keys
+// (eg using a KMS or a local master key)
+type KeyProvider interface {
+	// KeyId returns the id of the key used by WrapKey
+	KeyId() string
+	// WrapKey encrypts dataKey
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped with key keyId
+	UnwrapKey(keyId string, wrapped []byte) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider wrapping data keys with a static AES key (AES-GCM)
+type StaticKeyProvider struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewStaticKeyProvider returns a StaticKeyProvider using key (16, 24 or 32 bytes) identified by id
+func NewStaticKeyProvider(id string, key []byte) (*StaticKeyProvider, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticKeyProvider{id, aead}, nil
+}
+
+// KeyId returns the id of the key
+func (p *StaticKeyProvider) KeyId() string {
+	return p.id
+}
+
+// WrapKey encrypts dataKey, the nonce is prepended to the result
+func (p *StaticKeyProvider) WrapKey(dataKey []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return p.aead.Seal(nonce, nonce, dataKey, []byte(p.id)), nil
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey
+func (p *StaticKeyProvider) UnwrapKey(keyId string, wrapped []byte) ([]byte, error) {
+	if keyId != p.id {
+		return nil, gopenstack.ErrUnknownKey(keyId)
+	}
+	if len(wrapped) < p.aead.NonceSize() {
+		return nil, gopenstack.ErrDecryption
+	}
+	dataKey, err := p.aead.Open(nil, wrapped[:p.aead.NonceSize()], wrapped[p.aead.NonceSize():], []byte(p.id))
+	if err != nil {
+		return nil, gopenstack.ErrDecryption
+	}
+	return dataKey, nil
+}
+
+// newGCM returns an AES-GCM AEAD
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// objectCipher holds the encryption parameters of an object
+type objectCipher struct {
+	aead      cipher.AEAD
+	nonce     []byte
+	chunkSize int
+	key       []byte
+}
+
+// newObjectCipher generates a data key and returns the cipher and its metadata headers
+func newObjectCipher(provider KeyProvider) (*objectCipher, map[string]string, error) {
+	dataKey := make([]byte, 32)
+	nonce := make([]byte, 12)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := provider.WrapKey(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers := map[string]string{
+		hdrCryptoVersion:   cryptoVersion,
+		hdrCryptoAlg:       cryptoAlg,
+		hdrCryptoChunkSize: strconv.Itoa(cryptoChunkSize),
+		hdrCryptoNonce:     base64.StdEncoding.EncodeToString(nonce),
+		hdrCryptoKeyId:     provider.KeyId(),
+		hdrCryptoKey:       base64.StdEncoding.EncodeToString(wrapped),
+	}
+	return &objectCipher{aead, nonce, cryptoChunkSize, dataKey}, headers, nil
+}
+
+// isEncrypted returns true if object headers have encryption metadata
+func isEncrypted(headers http.Header) bool {
+	return headers.Get(hdrCryptoVersion) != ""
+}
+
+// isEncryptedHeaders returns true if upload headers have encryption metadata
+func isEncryptedHeaders(headers map[string]string) bool {
+	return headers[hdrCryptoVersion] != ""
+}
+
+// objectCipherFromHeaders returns the cipher of an object from its headers
+func objectCipherFromHeaders(headers http.Header, provider KeyProvider) (*objectCipher, error) {
+	if headers.Get(hdrCryptoVersion) != cryptoVersion || headers.Get(hdrCryptoAlg) != cryptoAlg {
+		return nil, gopenstack.ErrUnsupportedEncryption(headers.Get(hdrCryptoVersion), headers.Get(hdrCryptoAlg))
+	}
+	chunkSize, err := strconv.Atoi(headers.Get(hdrCryptoChunkSize))
+	if err != nil || chunkSize <= 0 || chunkSize > cryptoMaxChunkSize {
+		return nil, gopenstack.ErrDecryption
+	}
+	nonce, err := base64.StdEncoding.DecodeString(headers.Get(hdrCryptoNonce))
+	if err != nil || len(nonce) != 12 {
+		return nil, gopenstack.ErrDecryption
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(headers.Get(hdrCryptoKey))
+	if err != nil {
+		return nil, gopenstack.ErrDecryption
+	}
+	dataKey, err := provider.UnwrapKey(headers.Get(hdrCryptoKeyId), wrapped)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &objectCipher{aead, nonce, chunkSize, dataKey}, nil
+}
+
+// plainMac returns the HMAC of plain content md5 sum keyed by the data key
+func (c *objectCipher) plainMac(sum string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte("plain-md5:" + sum))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isEncryptedCopy returns true if headers are those of an object encrypted
+// with a key of provider and of plain content md5 sum
+func isEncryptedCopy(headers http.Header, provider KeyProvider, sum string) bool {
+	if !isEncrypted(headers) || provider == nil {
+		return false
+	}
+	c, err := objectCipherFromHeaders(headers, provider)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(headers.Get(hdrCryptoPlainMac)), []byte(c.plainMac(sum)))
+}
+
+// frameParams returns the nonce and additional data of frame index
+func (c *objectCipher) frameParams(index uint64, final bool) (nonce, ad []byte) {
+	nonce = make([]byte, len(c.nonce))
+	copy(nonce, c.nonce)
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], index)
+	for i := range counter {
+		nonce[4+i] ^= counter[i]
+	}
+	ad = make([]byte, 9)
+	copy(ad, counter[:])
+	if final {
+		ad[8] = 1
+	}
+	return
+}
+
+// encryptReader encrypts a plain text stream
+type encryptReader struct {
+	c     *objectCipher
+	src   io.Reader
+	index uint64
+	plain []byte
+	out   []byte
+	done  bool
+}
+
+// newEncryptReader returns a reader of src content encrypted with c
+func (c *objectCipher) newEncryptReader(src io.Reader) io.Reader {
+	return &encryptReader{c: c, src: src, plain: make([]byte, c.chunkSize)}
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.plain)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		final := n < r.c.chunkSize
+		nonce, ad := r.c.frameParams(r.index, final)
+		r.out = r.c.aead.Seal(r.out[:0], nonce, r.plain[:n], ad)
+		r.index++
+		r.done = final
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// decryptReader decrypts an encrypted stream
+type decryptReader struct {
+	c     *objectCipher
+	src   io.Reader
+	index uint64
+	frame []byte
+	out   []byte
+	done  bool
+}
+
+// newDecryptReader returns a reader of src content decrypted with c
+func (c *objectCipher) newDecryptReader(src io.Reader) io.Reader {
+	return &decryptReader{c: c, src: src, frame: make([]byte, c.chunkSize+cryptoTagSize)}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.frame)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			if err == io.EOF {
+				// final frame is missing
+				err = gopenstack.ErrDecryption
+			}
+			return 0, err
+		}
+		final := n < len(r.frame)
+		nonce, ad := r.c.frameParams(r.index, final)
+		if r.out, err = r.c.aead.Open(r.out[:0], nonce, r.frame[:n], ad); err != nil {
+			return 0, gopenstack.ErrDecryption
+		}
+		r.index++
+		r.done = final
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
@@ -0,0 +1,186 @@
+package objectStorageV1_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+)
+
+// newKeyProvider returns a StaticKeyProvider with a key filled with b
+func newKeyProvider(t *testing.T, id string, b byte) objectStorageV1.KeyProvider {
+	provider, err := objectStorageV1.NewStaticKeyProvider(id, []byte(strings.Repeat(string(b), 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestEncryptionMetadata(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"f.txt": "secret"})
+	src := filepath.Join(dir, "f.txt")
+	options := &objectStorageV1.PutOptions{KeyProvider: newKeyProvider(t, "k1", 1)}
+
+	if err := s.PutFileWithOptions(src, "/c/f.txt", options); err != nil {
+		t.Fatal(err)
+	}
+	o, err := s.HeadObject("/c/f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range o.Metadata {
+		if strings.HasPrefix(k, "Crypto-Plain-") && k != "Crypto-Plain-Hmac" || strings.HasPrefix(k, "Original-") || v == md5sum("secret") {
+			t.Errorf("plain content information in clear metadata: %s: %s", k, v)
+		}
+	}
+
+	// unchanged file: no upload
+	srv.ResetRequests()
+	if err = s.PutFileWithOptions(src, "/c/f.txt", options); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" {
+			t.Error("unchanged encrypted file uploaded again")
+		}
+	}
+
+	// another key can not tell the file is unchanged
+	srv.ResetRequests()
+	other := &objectStorageV1.PutOptions{KeyProvider: newKeyProvider(t, "k2", 2)}
+	if err = s.PutFileWithOptions(src, "/c/f.txt", other); err != nil {
+		t.Fatal(err)
+	}
+	uploaded := false
+	for _, r := range srv.Requests() {
+		uploaded = uploaded || r.Method == "PUT"
+	}
+	if !uploaded {
+		t.Error("file encrypted with another key not uploaded")
+	}
+
+	// verification needs the key
+	mismatches, err := s.VerifyWithOptions(dir, "/c", &objectStorageV1.CopyOptions{KeyProvider: other.KeyProvider})
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("VerifyWithOptions: %+v, %v", mismatches, err)
+	}
+	mismatches, err = s.Verify(dir, "/c")
+	if err != nil || len(mismatches) != 1 || mismatches[0].Reason != "checksum" {
+		t.Errorf("Verify without key: %+v, %v", mismatches, err)
+	}
+}
+
+func TestCompressedEncryptedMetadata(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"f.log": strings.Repeat("log line\n", 100)})
+	src := filepath.Join(dir, "f.log")
+	options := &objectStorageV1.PutOptions{
+		KeyProvider: newKeyProvider(t, "k1", 1),
+		Compression: &objectStorageV1.Compression{Algorithm: objectStorageV1.CompressGzip},
+	}
+
+	if err := s.PutFileWithOptions(src, "/c/f.log", options); err != nil {
+		t.Fatal(err)
+	}
+	o, err := s.HeadObject("/c/f.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Metadata["Compression"] != objectStorageV1.CompressGzip {
+		t.Errorf("compression metadata: %v", o.Metadata)
+	}
+	for _, k := range []string{"Original-Md5", "Original-Size"} {
+		if v, ok := o.Metadata[k]; ok {
+			t.Errorf("%s of an encrypted object stored in clear: %s", k, v)
+		}
+	}
+
+	// unchanged file is still detected with the key
+	srv.ResetRequests()
+	if err = s.PutFileWithOptions(src, "/c/f.log", options); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" {
+			t.Error("unchanged compressed encrypted file uploaded again")
+		}
+	}
+
+	dest := filepath.Join(t.TempDir(), "f.log")
+	err = s.DownloadObjectWithOptions("/c/f.log", dest, &objectStorageV1.CopyOptions{KeyProvider: options.KeyProvider, Decompress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(dest); err != nil || string(data) != strings.Repeat("log line\n", 100) {
+		t.Errorf("downloaded content: %d bytes, %v", len(data), err)
+	}
+}
+
+func TestDownloadEncryptedWithoutKey(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	provider := newKeyProvider(t, "k1", 1)
+	err := s.PutObject("/c/o", strings.NewReader("secret"), &objectStorageV1.PutOptions{KeyProvider: provider})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "o")
+	if err = s.DownloadObject("/c/o", dest); err != gopenstack.ErrNoKeyProvider {
+		t.Errorf("DownloadObject without key: %v", err)
+	}
+	if _, err = os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("ciphertext written: %v", err)
+	}
+	if _, err = s.GetObject("/c/o", nil); err != gopenstack.ErrNoKeyProvider {
+		t.Errorf("GetObject without key: %v", err)
+	}
+
+	if err = s.DownloadObjectWithOptions("/c/o", dest, &objectStorageV1.CopyOptions{KeyProvider: provider}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(dest); err != nil || string(data) != "secret" {
+		t.Errorf("decrypted content: %q, %v", data, err)
+	}
+}
+
+func TestDownloadEncryptedChunkSize(t *testing.T) {
+	_, s, client := newTestSwift(t)
+	putObjects(t, s, nil)
+	provider := newKeyProvider(t, "k1", 1)
+	err := s.PutObject("/c/o", strings.NewReader("secret"), &objectStorageV1.PutOptions{KeyProvider: provider})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// metadata are replaced by POST: send them back with a huge chunk size
+	resp, err := client.Call(&gopenstack.CallOptions{Method: "HEAD", Ressource: "c/o"})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		t.Fatal(err)
+	}
+	headers := make(map[string]string)
+	for k := range resp.Headers {
+		if strings.HasPrefix(k, "X-Object-Meta-") {
+			headers[k] = resp.Headers.Get(k)
+		}
+	}
+	headers["X-Object-Meta-Crypto-Chunk-Size"] = "1099511627776"
+	resp, err = client.Call(&gopenstack.CallOptions{Method: "POST", Ressource: "c/o", Headers: headers})
+	if err = resp.HandleErr(err, []int{202}); err != nil {
+		t.Fatal(err)
+	}
+
+	options := &objectStorageV1.CopyOptions{KeyProvider: provider}
+	if _, err = s.GetObject("/c/o", options); err != gopenstack.ErrDecryption {
+		t.Errorf("GetObject with a huge chunk size: %v", err)
+	}
+}
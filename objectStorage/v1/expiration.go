@@ -0,0 +1,77 @@
+package objectStorageV1
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// SetObjectDeleteAt schedules deletion of object at path at date deleteAt
+func (s *Swift) SetObjectDeleteAt(path string, deleteAt time.Time) error {
+	return s.postObject(path, (&PutOptions{DeleteAt: deleteAt}).headers())
+}
+
+// SetObjectDeleteAfter schedules deletion of object at path in ttl
+func (s *Swift) SetObjectDeleteAfter(path string, ttl time.Duration) error {
+	return s.postObject(path, (&PutOptions{DeleteAfter: ttl}).headers())
+}
+
+// RemoveObjectExpiration cancels scheduled deletion of object at path
+func (s *Swift) RemoveObjectExpiration(path string) error {
+	headers := make(map[string]string)
+	headers["X-Remove-Delete-At"] = "x"
+	return s.postObject(path, headers)
+}
+
+// postObject updates object headers
+// As a POST replaces all object metadata, existing metadata are sent back
+func (s *Swift) postObject(path string, headers map[string]string) error {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: escapePath(path),
+	})
+	if err = resp.HandleErr(err, []int{200, 204}); err != nil {
+		return err
+	}
+	h := make(map[string]string)
+	for k, v := range resp.Headers {
+		if strings.HasPrefix(k, "X-Object-Meta-") && len(v) > 0 {
+			h[k] = v[0]
+		}
+	}
+	for _, k := range []string{"Content-Type", "Content-Encoding", "Content-Disposition"} {
+		if v := resp.Headers.Get(k); v != "" {
+			h[k] = v
+		}
+	}
+	if da := resp.Headers.Get("X-Delete-At"); da != "" {
+		h["X-Delete-At"] = da
+	}
+	for k, v := range headers {
+		h[k] = v
+	}
+	if _, ok := headers["X-Delete-After"]; ok {
+		delete(h, "X-Delete-At")
+	}
+	if _, ok := headers["X-Remove-Delete-At"]; ok {
+		delete(h, "X-Delete-At")
+	}
+
+	resp, err = s.client.Call(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: escapePath(path),
+		Headers:   h,
+	})
+	return resp.HandleErr(err, []int{202})
+}
+
+// parseDeleteAt returns the expiration date from a X-Delete-At header value
+func parseDeleteAt(value string) (t time.Time) {
+	ts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+	return time.Unix(ts, 0)
+}
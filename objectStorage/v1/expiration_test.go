@@ -0,0 +1,77 @@
+package objectStorageV1_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+)
+
+func TestDeleteAfterRoundedUp(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	for ttl, expected := range map[time.Duration]string{300 * time.Millisecond: "1", 1500 * time.Millisecond: "2", time.Minute: "60"} {
+		srv.ResetRequests()
+		err := s.PutObject("/c/o", strings.NewReader("x"), &objectStorageV1.PutOptions{DeleteAfter: ttl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range srv.Requests() {
+			if got := r.Header.Get("X-Delete-After"); r.Method == "PUT" && got != expected {
+				t.Errorf("DeleteAfter %v: X-Delete-After %q, expected %q", ttl, got, expected)
+			}
+		}
+		o, err := s.HeadObject("/c/o")
+		if err != nil || o.DeleteAt.IsZero() || !o.DeleteAt.After(time.Now().Add(-time.Second)) {
+			t.Errorf("DeleteAfter %v: delete at %v, %v", ttl, o.DeleteAt, err)
+		}
+	}
+}
+
+func TestPutFileUnchangedHeaders(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"f.log": strings.Repeat("log line\n", 100)})
+	src := filepath.Join(dir, "f.log")
+	compression := &objectStorageV1.Compression{Algorithm: objectStorageV1.CompressGzip}
+
+	err := s.PutFileWithOptions(src, "/c/f.log", &objectStorageV1.PutOptions{
+		Compression: compression,
+		Headers:     map[string]string{"X-Object-Meta-Color": "blue"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unchanged file: headers are updated without upload
+	srv.ResetRequests()
+	err = s.PutFileWithOptions(src, "/c/f.log", &objectStorageV1.PutOptions{
+		Compression: compression,
+		Headers:     map[string]string{"X-Object-Meta-Color": "red", "Content-Disposition": "attachment"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	posted := false
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" {
+			t.Error("unchanged file uploaded again")
+		}
+		if r.Method == "POST" {
+			posted = true
+			if r.Header.Get("Content-Disposition") != "attachment" || r.Header.Get("Content-Encoding") != "gzip" {
+				t.Errorf("POST headers: %v", r.Header)
+			}
+		}
+	}
+	if !posted {
+		t.Error("headers of unchanged file not updated")
+	}
+	o, err := s.HeadObject("/c/f.log")
+	if err != nil || o.Metadata["Color"] != "red" || o.Metadata["Compression"] != objectStorageV1.CompressGzip {
+		t.Errorf("metadata after update: %v, %v", o.Metadata, err)
+	}
+}
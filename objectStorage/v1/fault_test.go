@@ -0,0 +1,193 @@
+package objectStorageV1_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+	"github.com/Toorop/gopenstack/objectStorage/v1/swifttest"
+)
+
+// isHttpError returns true if err is an HTTP error with status code
+func isHttpError(err error, code int) bool {
+	var httpErr *gopenstack.HttpError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == code
+}
+
+func TestPutFileHeadError(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"f.txt": "content"})
+	srv.AddFault(swifttest.Fault{Method: "HEAD", Path: "/c/f.txt", StatusCode: 500})
+
+	srv.ResetRequests()
+	if err := s.PutFile(filepath.Join(dir, "f.txt"), "/c/f.txt"); !isHttpError(err, 500) {
+		t.Errorf("PutFile with a failing HEAD: %v", err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" {
+			t.Error("object uploaded after a failed HEAD")
+		}
+	}
+}
+
+func TestPutTransientError(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, filepath.Join(dir, "tree"), map[string]string{"a.txt": "a", "b.txt": "b"})
+	fault := srv.AddFault(swifttest.Fault{Method: "PUT", Path: "/c/tree/b.txt", StatusCode: 503, Times: 1})
+
+	if err := s.Put(filepath.Join(dir, "tree"), "/c"); !isHttpError(err, 503) {
+		t.Fatalf("Put with a failing PUT: %v", err)
+	}
+	if srv.Injected(fault) != 1 {
+		t.Fatalf("fault injected %d times", srv.Injected(fault))
+	}
+
+	// retrying uploads the failed file only
+	srv.ResetRequests()
+	if err := s.Put(filepath.Join(dir, "tree"), "/c"); err != nil {
+		t.Fatalf("Put retry: %v", err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" && r.Path != "/c/tree/b.txt" {
+			t.Errorf("unchanged %s uploaded again", r.Path)
+		}
+	}
+	for name, content := range map[string]string{"a.txt": "a", "b.txt": "b"} {
+		if o, err := s.HeadObject("/c/tree/" + name); err != nil || o.Hash != md5sum(content) {
+			t.Errorf("%s: %q, %v", name, o.Hash, err)
+		}
+	}
+}
+
+func TestPutWrongEtag(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, filepath.Join(dir, "tree"), map[string]string{"a.txt": "a"})
+	srv.AddFault(swifttest.Fault{Method: "PUT", Path: "/c/tree/a.txt", WrongEtag: true})
+
+	err := s.PutWithOptions(filepath.Join(dir, "tree"), "/c", &objectStorageV1.PutOptions{Verify: true})
+	if err == nil {
+		t.Error("Put with verification accepted a wrong etag")
+	}
+	if err = s.Put(filepath.Join(dir, "tree"), "/c"); err != nil {
+		t.Errorf("Put without verification: %v", err)
+	}
+}
+
+func TestDownloadPathTruncated(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "aaaa", "dir/b.txt": strings.Repeat("b", 4096)})
+	srv.AddFault(swifttest.Fault{Method: "GET", Path: "/c/dir/b.txt", TruncateBody: 10})
+
+	dest := t.TempDir()
+	if err := s.DownloadPath("/c/dir", dest); err == nil {
+		t.Fatal("DownloadPath of a truncated object succeeded")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "dir", "b.txt")); !os.IsNotExist(err) {
+		t.Errorf("partial file kept: %v", err)
+	}
+}
+
+func TestDownloadPathError(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "a", "dir/b.txt": "b", "dir/c.txt": "c"})
+	srv.AddFault(swifttest.Fault{Method: "GET", Path: "/c/dir/", Nth: 2, Times: 1, StatusCode: 500})
+
+	if err := s.DownloadPath("/c/dir", t.TempDir()); !isHttpError(err, 500) {
+		t.Errorf("DownloadPath with a failing GET: %v", err)
+	}
+}
+
+func TestDeletePathError(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"a.txt": "a"})
+	srv.AddFault(swifttest.Fault{Method: "POST", Path: "/", StatusCode: 503})
+
+	if err := s.DeletePath("/c"); !isHttpError(err, 503) {
+		t.Errorf("DeletePath with a failing bulk-delete: %v", err)
+	}
+	if _, err := s.HeadContainer("c"); err != nil {
+		t.Errorf("container removed: %v", err)
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "a"})
+	dir := t.TempDir()
+	writeFiles(t, filepath.Join(dir, "tree"), map[string]string{"a.txt": "a"})
+	srv.ExpireToken()
+
+	if err := s.Put(filepath.Join(dir, "tree"), "/c"); !isHttpError(err, 401) {
+		t.Errorf("Put with an expired token: %v", err)
+	}
+	if err := s.DownloadPath("/c/dir", t.TempDir()); !isHttpError(err, 401) {
+		t.Errorf("DownloadPath with an expired token: %v", err)
+	}
+	if err := s.DeletePath("/c/dir"); !isHttpError(err, 401) {
+		t.Errorf("DeletePath with an expired token: %v", err)
+	}
+
+	// a new token works and nothing was deleted
+	s2, err := srv.Swift()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s2.HeadObject("/c/dir/a.txt"); err != nil {
+		t.Errorf("object removed: %v", err)
+	}
+}
+
+func TestUnauthorizedDuringDelete(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "a", "dir/b.txt": "b"})
+	// token expires between the listing and the deletion
+	srv.AddFault(swifttest.Fault{Method: "POST", Path: "/", Unauthorized: true})
+
+	if err := s.DeletePath("/c/dir"); !isHttpError(err, 401) {
+		t.Errorf("DeletePath: %v", err)
+	}
+	if _, err := s.HeadObject("/c/dir/a.txt"); err != nil {
+		t.Errorf("object removed: %v", err)
+	}
+}
+
+func TestFaultDelay(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	srv.AddFault(swifttest.Fault{Path: "/info", Delay: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	if _, err := s.Capabilities(); err != nil {
+		t.Fatalf("Capabilities with a delayed response: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response not delayed (%s)", elapsed)
+	}
+
+	// a client timeout cancels the delayed request
+	srv.AddFault(swifttest.Fault{Path: "/info", Delay: time.Minute})
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	start = time.Now()
+	resp, err := client.Get(srv.URL + "/info")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("delayed request did not time out")
+	}
+	if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+		t.Errorf("delayed request: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("timeout after %s", elapsed)
+	}
+}
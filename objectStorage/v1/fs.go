@@ -0,0 +1,331 @@
+package objectStorageV1
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// FS is a read only io/fs file system over a swift account
+// Top level directories are containers, vfolders are directories and objects are files.
+// Use fs.Sub(fsys, container) to get a file system rooted on a container.
+type FS struct {
+	swift *Swift
+}
+
+// NewFS returns a FS over the account of s
+func NewFS(s *Swift) *FS {
+	return &FS{s}
+}
+
+// Open opens the named file or directory
+// Files are streamed from the object storage
+func (f *FS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	p := NewOsPath(f.swift.client, name)
+	if p.path.Kind() == KindObject {
+		resp, err := f.swift.client.Call(&gopenstack.CallOptions{
+			Method:             "GET",
+			Ressource:          escapePath(p.Name),
+			ReturnBodyAsReader: true,
+		})
+		if err = resp.HandleErr(err, []int{200, 404}); err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		if resp.StatusCode == 200 {
+			o := objectFromHeaders(p.path.Key, f.swift.account(), resp.Headers)
+			return &fsFile{swift: f.swift, path: escapePath(p.Name), info: objectFileInfo(&o), body: resp.BodyReader}, nil
+		}
+		resp.BodyReader.Close()
+	}
+	info, err := f.stat(p, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &fsDir{fsys: f, name: name, info: info}, nil
+}
+
+// Stat returns a FileInfo describing the named file or directory
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := f.stat(NewOsPath(f.swift.client, name), name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// stat returns FileInfo of p
+func (f *FS) stat(p *osPath, name string) (*fsFileInfo, error) {
+	if name == "." {
+		return &fsFileInfo{name: ".", dir: true}, nil
+	}
+	pathType, err := p.GetType()
+	if err != nil {
+		return nil, err
+	}
+	switch pathType {
+	case KindRoot, KindContainer, KindVfolder:
+		return &fsFileInfo{name: path.Base(name), dir: true}, nil
+	}
+	// objects and symlinks (followed)
+	resp, err := f.swift.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: escapePath(p.Name),
+	})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == 404 {
+		return nil, fs.ErrNotExist
+	}
+	o := objectFromHeaders(p.path.Key, f.swift.account(), resp.Headers)
+	return objectFileInfo(&o), nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by filename
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, err := f.readDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// readDir returns entries of directory name
+func (f *FS) readDir(name string) (entries []fs.DirEntry, err error) {
+	if name == "." {
+		containers, err := f.swift.ListContainers()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range containers {
+			entries = append(entries, fs.FileInfoToDirEntry(&fsFileInfo{name: c.Name, dir: true}))
+		}
+		return entries, nil
+	}
+	p := NewOsPath(f.swift.client, name)
+	pathType, err := p.GetType()
+	if err != nil {
+		return nil, err
+	}
+	if pathType != KindContainer && pathType != KindVfolder {
+		return nil, gopenstack.ErrNotADirectory(name)
+	}
+	children, err := p.ListDir()
+	if err != nil {
+		return nil, err
+	}
+	prefix := p.GetPrefix()
+	for k := range children {
+		var info *fsFileInfo
+		if children[k].IsSubdir() {
+			info = &fsFileInfo{name: strings.TrimSuffix(children[k].Subdir[len(prefix):], "/"), dir: true}
+		} else {
+			info = objectFileInfo(&children[k])
+			info.name = children[k].Name[len(prefix):]
+		}
+		// skip directory markers
+		if info.name == "" || strings.Contains(info.name, "/") {
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// fsFileInfo implements fs.FileInfo
+type fsFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+	object  *Object
+}
+
+// objectFileInfo returns FileInfo of object o
+// ModTime is truncated to the second as HEAD responses (Last-Modified) are less precise than listings
+func objectFileInfo(o *Object) *fsFileInfo {
+	return &fsFileInfo{name: path.Base(o.Name), size: int64(o.Bytes), modTime: o.LastModified.Time.Truncate(time.Second).UTC(), object: o}
+}
+
+func (i *fsFileInfo) Name() string       { return i.name }
+func (i *fsFileInfo) Size() int64        { return i.size }
+func (i *fsFileInfo) ModTime() time.Time { return i.modTime }
+func (i *fsFileInfo) IsDir() bool        { return i.dir }
+func (i *fsFileInfo) Sys() interface{}   { return i.object }
+func (i *fsFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// fsFile is an object opened for reading
+// It implements io.Seeker and io.ReaderAt (as needed by http.FS) using ranged GETs
+type fsFile struct {
+	swift      *Swift
+	path       string // escaped object path
+	info       *fsFileInfo
+	body       io.ReadCloser // current body, nil after a seek
+	bodyOffset int64         // offset of the next byte of body
+	offset     int64         // read offset
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// Read reads from the current offset, reopening the object at this offset after a seek
+func (f *fsFile) Read(b []byte) (int, error) {
+	if f.body != nil && f.bodyOffset != f.offset {
+		f.body.Close()
+		f.body = nil
+	}
+	if f.body == nil {
+		if f.offset >= f.info.size {
+			return 0, io.EOF
+		}
+		body, err := f.get(f.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		f.body, f.bodyOffset = body, f.offset
+	}
+	n, err := f.body.Read(b)
+	f.offset += int64(n)
+	f.bodyOffset = f.offset
+	return n, err
+}
+
+// Seek sets the offset of the next Read
+func (f *fsFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	default:
+		return f.offset, &fs.PathError{Op: "seek", Path: f.info.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return f.offset, &fs.PathError{Op: "seek", Path: f.info.name, Err: fs.ErrInvalid}
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// ReadAt reads len(b) bytes at offset off with a ranged GET
+func (f *fsFile) ReadAt(b []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &fs.PathError{Op: "readat", Path: f.info.name, Err: fs.ErrInvalid}
+	}
+	if off >= f.info.size {
+		return 0, io.EOF
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	body, err := f.get(off, off+int64(len(b))-1)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	n, err := io.ReadFull(body, b)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// get returns the object content from start to end (included, to the end if negative)
+func (f *fsFile) get(start, end int64) (io.ReadCloser, error) {
+	r := fmt.Sprintf("bytes=%d-", start)
+	if end >= 0 {
+		r += strconv.FormatInt(end, 10)
+	}
+	resp, err := f.swift.client.Call(&gopenstack.CallOptions{
+		Method:             "GET",
+		Ressource:          f.path,
+		Headers:            map[string]string{"Range": r},
+		ReturnBodyAsReader: true,
+	})
+	if err = resp.HandleErr(err, []int{200, 206}); err != nil {
+		if resp.BodyReader != nil {
+			resp.BodyReader.Close()
+		}
+		return nil, &fs.PathError{Op: "read", Path: f.info.name, Err: err}
+	}
+	// range not honored: skip bytes before start
+	if resp.StatusCode == 200 && start > 0 {
+		if _, err = io.CopyN(ioutil.Discard, resp.BodyReader, start); err != nil {
+			resp.BodyReader.Close()
+			return nil, &fs.PathError{Op: "read", Path: f.info.name, Err: err}
+		}
+	}
+	return resp.BodyReader, nil
+}
+
+func (f *fsFile) Close() error {
+	if f.body == nil {
+		return nil
+	}
+	err := f.body.Close()
+	f.body = nil
+	return err
+}
+
+// fsDir is a container or vfolder opened for reading
+type fsDir struct {
+	fsys    *FS
+	name    string
+	info    *fsFileInfo
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *fsDir) Close() error               { return nil }
+func (d *fsDir) Read(b []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: gopenstack.ErrNotAFile(d.name)}
+}
+
+// ReadDir returns the next n entries of d (all remaining entries if n <= 0)
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.readDir(d.name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: err}
+		}
+		d.entries, d.read = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,105 @@
+package objectStorageV1_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+)
+
+func TestFS(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"a.txt": "a", "dir/b.txt": "bb", "dir/sub/c.txt": "ccc"})
+	fsys := objectStorageV1.NewFS(s)
+	if err := fstest.TestFS(fsys, "c/a.txt", "c/dir/b.txt", "c/dir/sub/c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	sub, err := fs.Sub(fsys, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := fs.ReadFile(sub, "dir/sub/c.txt")
+	if err != nil || string(data) != "ccc" {
+		t.Errorf("ReadFile: %q, %v", data, err)
+	}
+	if _, err = fs.Stat(sub, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat of a missing file: %v", err)
+	}
+}
+
+func TestFSFileServer(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	content := "<html><body>0123456789</body></html>"
+	putObjects(t, s, map[string]string{"page": content})
+	sub, err := fs.Sub(objectStorageV1.NewFS(s), "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.FileServer(http.FS(sub)))
+	defer srv.Close()
+
+	// full content, type sniffed (no extension)
+	resp, err := http.Get(srv.URL + "/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 || string(data) != content || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("GET: %d %q %q", resp.StatusCode, resp.Header.Get("Content-Type"), data)
+	}
+
+	// range
+	req, _ := http.NewRequest("GET", srv.URL+"/page", nil)
+	req.Header.Set("Range", "bytes=12-15")
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent || string(data) != "0123" {
+		t.Errorf("GET with range: %d %q", resp.StatusCode, data)
+	}
+}
+
+func TestFSFileSeekReadAt(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"o": "0123456789"})
+	f, err := objectStorageV1.NewFS(s).Open("c/o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b := make([]byte, 3)
+	if n, err := f.Read(b); err != nil || string(b[:n]) != "012" {
+		t.Errorf("Read: %q, %v", b[:n], err)
+	}
+	seeker := f.(io.Seeker)
+	if off, err := seeker.Seek(-4, io.SeekEnd); err != nil || off != 6 {
+		t.Fatalf("Seek: %d, %v", off, err)
+	}
+	if data, err := ioutil.ReadAll(f); err != nil || string(data) != "6789" {
+		t.Errorf("Read after seek: %q, %v", data, err)
+	}
+	if _, err := seeker.Seek(-1, io.SeekStart); err == nil {
+		t.Error("Seek to a negative offset succeeded")
+	}
+
+	readerAt := f.(io.ReaderAt)
+	if n, err := readerAt.ReadAt(b, 4); err != nil || string(b[:n]) != "456" {
+		t.Errorf("ReadAt: %q, %v", b[:n], err)
+	}
+	if n, err := readerAt.ReadAt(b, 8); err != io.EOF || string(b[:n]) != "89" {
+		t.Errorf("ReadAt at the end: %q, %v", b[:n], err)
+	}
+	if _, err := readerAt.ReadAt(b, 10); err != io.EOF {
+		t.Errorf("ReadAt after the end: %v", err)
+	}
+}
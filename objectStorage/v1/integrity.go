@@ -0,0 +1,313 @@
+package objectStorageV1
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Mismatch represents a difference found by Verify
+type Mismatch struct {
+	Path       string // Path relative to the verified roots
+	LocalHash  string
+	RemoteHash string
+	Reason     string // checksum, size, missing local, missing remote
+}
+
+// sloSegment represents a segment of a static large object manifest
+type sloSegment struct {
+	Name   string `json:"name"`
+	Hash   string `json:"hash"`
+	Bytes  int64  `json:"bytes"`
+	Range  string `json:"range"`
+	SubSlo bool   `json:"sub_slo"`
+}
+
+// getSloSegments returns segments of static large object path
+// ok is false if segments can not be used to verify data (ranges, nested manifests)
+func (s *Swift) getSloSegments(path string) (segments []sloSegment, ok bool, err error) {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "GET",
+		Ressource: path + "?multipart-manifest=get",
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return
+	}
+	if err = json.Unmarshal(resp.Body, &segments); err != nil {
+		return
+	}
+	for _, seg := range segments {
+		if seg.Range != "" || seg.SubSlo {
+			return segments, false, nil
+		}
+	}
+	return segments, true, nil
+}
+
+// A verifier hashes written data by segments and compares them to expected etags
+type verifier struct {
+	segments []sloSegment // a single segment for plain objects
+	current  int
+	written  int64
+	h        hash.Hash
+	skip     bool // data can not be verified
+}
+
+// newVerifier returns a verifier for object path (escaped) and its GET response headers
+func (s *Swift) newVerifier(path string, headers http.Header) (*verifier, error) {
+	v := &verifier{h: md5.New()}
+	etag := strings.Trim(headers.Get("Etag"), `"`)
+	switch {
+	case strings.EqualFold(headers.Get("X-Static-Large-Object"), "true"):
+		segments, ok, err := s.getSloSegments(path)
+		if err != nil {
+			return nil, err
+		}
+		v.segments, v.skip = segments, !ok
+	case headers.Get("X-Object-Manifest") != "" || etag == "":
+		// dynamic large objects etags can not be checked
+		v.skip = true
+	default:
+		v.segments = []sloSegment{{Hash: etag, Bytes: -1}}
+	}
+	return v, nil
+}
+
+// Write hashes p, switching segment at segments boundaries
+func (v *verifier) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 && !v.skip {
+		if v.current >= len(v.segments) {
+			return n, gopenstack.ErrChecksumMismatch("", "end of data", "extra data")
+		}
+		seg := v.segments[v.current]
+		chunk := p
+		if seg.Bytes >= 0 && int64(len(chunk)) > seg.Bytes-v.written {
+			chunk = chunk[:seg.Bytes-v.written]
+		}
+		v.h.Write(chunk)
+		v.written += int64(len(chunk))
+		p = p[len(chunk):]
+		if seg.Bytes >= 0 && v.written == seg.Bytes {
+			if err := v.next(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// next checks the current segment and switches to the next one
+func (v *verifier) next() error {
+	seg := v.segments[v.current]
+	if got := fmt.Sprintf("%x", v.h.Sum(nil)); got != seg.Hash {
+		return gopenstack.ErrChecksumMismatch(seg.Name, seg.Hash, got)
+	}
+	v.current++
+	v.written = 0
+	v.h.Reset()
+	return nil
+}
+
+// check returns an error if written data do not match expected etags
+func (v *verifier) check(path string) error {
+	if v.skip {
+		return nil
+	}
+	// plain object (unknown size)
+	if len(v.segments) == 1 && v.segments[0].Bytes < 0 {
+		if got := fmt.Sprintf("%x", v.h.Sum(nil)); got != v.segments[0].Hash {
+			return gopenstack.ErrChecksumMismatch(path, v.segments[0].Hash, got)
+		}
+		return nil
+	}
+	if v.current != len(v.segments) {
+		return gopenstack.ErrChecksumMismatch(path, "complete data", "truncated data")
+	}
+	return nil
+}
+
+// isSlo returns true if listing entry o is a static large object
+func (o *Object) isSlo() bool {
+	if o.SloEtag != "" {
+		return true
+	}
+	_, hasSwiftBytes := o.ContentTypeParams["swift_bytes"]
+	_, hasSloEtag := o.ContentTypeParams["slo_etag"]
+	return hasSwiftBytes || hasSloEtag
+}
+
+// sloEtag returns the etag of the static large object o (listing hash is the manifest one)
+func (s *Swift) sloEtag(path string, o *Object) (string, error) {
+	if o.SloEtag != "" {
+		return strings.Trim(o.SloEtag, `"`), nil
+	}
+	if etag := o.ContentTypeParams["slo_etag"]; etag != "" {
+		return strings.Trim(etag, `"`), nil
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: path,
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return "", err
+	}
+	return strings.Trim(resp.Headers.Get("Etag"), `"`), nil
+}
+
+// isTransformedCopy returns true if path is a compressed or encrypted object of plain content md5 sum
+// (encrypted objects can only be checked with the provider of their key)
+func (s *Swift) isTransformedCopy(path, sum string, provider KeyProvider) bool {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: path,
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return false
+	}
+	if isEncrypted(resp.Headers) {
+		return isEncryptedCopy(resp.Headers, provider, sum)
+	}
+	return compressionOf(resp.Headers) != "" && resp.Headers.Get(hdrOriginalMd5) == sum
+}
+
+// localEtag returns the etag the cluster would have for local file path
+// segments are used for static large objects (md5 of segments md5),
+// the etag is empty if the file size differs from the total size of segments
+func localEtag(path string, segments []sloSegment) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if segments == nil {
+		h := md5.New()
+		if _, err = io.Copy(h, f); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%x", h.Sum(nil)), nil
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	var size int64
+	for _, seg := range segments {
+		size += seg.Bytes
+	}
+	if info.Size() != size {
+		return "", nil
+	}
+	etags := md5.New()
+	for _, seg := range segments {
+		h := md5.New()
+		if _, err = io.CopyN(h, f, seg.Bytes); err == io.EOF || err == io.ErrUnexpectedEOF {
+			// truncated while reading
+			return "", nil
+		} else if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(etags, "%x", h.Sum(nil))
+	}
+	return fmt.Sprintf("%x", etags.Sum(nil)), nil
+}
+
+// Verify compares files under localPath with objects under remotePath
+// (container or vfolder) using checksums, no data is transferred.
+// To check a Put(src, dest) use Verify(src, dest+"/"+filepath.Base(src)).
+func (s *Swift) Verify(localPath, remotePath string) (mismatches []Mismatch, err error) {
+	return s.VerifyWithOptions(localPath, remotePath, nil)
+}
+
+// VerifyWithOptions compares files under localPath with objects under remotePath
+// options.KeyProvider is needed to verify encrypted objects, they are reported
+// as checksum mismatches without it
+func (s *Swift) VerifyWithOptions(localPath, remotePath string, options *CopyOptions) (mismatches []Mismatch, err error) {
+	var provider KeyProvider
+	if options != nil {
+		provider = options.KeyProvider
+	}
+	remote := NewOsPath(s.client, remotePath)
+	rp, err := remote.GetPath()
+	if err != nil {
+		return
+	}
+	if rp.IsRoot() {
+		return mismatches, gopenstack.ErrNoContainerSpecified
+	}
+	objects, err := remote.GetChildrenObjects()
+	if err != nil {
+		return
+	}
+	remoteObjects := make(map[string]Object)
+	for _, o := range objects {
+		remoteObjects[o.Name[len(rp.Prefix()):]] = o
+	}
+
+	localFiles := make(map[string]string)
+	err = filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(localPath, path)
+		if err != nil {
+			return err
+		}
+		localFiles[filepath.ToSlash(rel)] = path
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for rel, path := range localFiles {
+		o, ok := remoteObjects[rel]
+		if !ok {
+			mismatches = append(mismatches, Mismatch{Path: rel, Reason: "missing remote"})
+			continue
+		}
+		var segments []sloSegment
+		expected := o.Hash
+		if o.isSlo() {
+			var usable bool
+			src := escapePath(rp.Container + "/" + o.Name)
+			segments, usable, err = s.getSloSegments(src)
+			if err != nil {
+				return
+			}
+			if !usable {
+				continue
+			}
+			if expected, err = s.sloEtag(src, &o); err != nil {
+				return
+			}
+		}
+		sum, err := localEtag(path, segments)
+		if err != nil {
+			return mismatches, err
+		}
+		if sum == "" {
+			mismatches = append(mismatches, Mismatch{Path: rel, RemoteHash: expected, Reason: "size"})
+			continue
+		}
+		if sum != expected && !s.isTransformedCopy(escapePath(rp.Container+"/"+o.Name), sum, provider) {
+			mismatches = append(mismatches, Mismatch{Path: rel, LocalHash: sum, RemoteHash: expected, Reason: "checksum"})
+		}
+	}
+	for rel, o := range remoteObjects {
+		if _, ok := localFiles[rel]; !ok {
+			mismatches = append(mismatches, Mismatch{Path: rel, RemoteHash: o.Hash, Reason: "missing local"})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Path < mismatches[j].Path })
+	return
+}
@@ -0,0 +1,61 @@
+package objectStorageV1_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+	"github.com/Toorop/gopenstack/objectStorage/v1/swifttest"
+)
+
+func TestDownloadPathVerify(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "a", "dir/b.txt": "b"})
+	srv.AddFault(swifttest.Fault{Method: "GET", Path: "/c/dir/b.txt", WrongEtag: true})
+
+	dest := t.TempDir()
+	err := s.DownloadPathWithOptions("/c/dir", dest, &objectStorageV1.CopyOptions{Verify: true})
+	var mismatch *gopenstack.ChecksumMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("DownloadPath with verification: %v", err)
+	}
+	if mismatch.Path != "c/dir/b.txt" || mismatch.Got != md5sum("b") {
+		t.Errorf("mismatch: %+v", mismatch)
+	}
+	if _, err = os.Stat(filepath.Join(dest, "dir", "b.txt")); !os.IsNotExist(err) {
+		t.Errorf("corrupted file kept: %v", err)
+	}
+
+	// without verification the etag is not checked
+	if err = s.DownloadPath("/c/dir", t.TempDir()); err != nil {
+		t.Errorf("DownloadPath without verification: %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"tree/a.txt": "a", "tree/b.txt": "changed", "tree/remote.txt": "r"})
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.txt": "a", "b.txt": "b", "local.txt": "l"})
+
+	mismatches, err := s.Verify(dir, "/c/tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []objectStorageV1.Mismatch{
+		{Path: "b.txt", LocalHash: md5sum("b"), RemoteHash: md5sum("changed"), Reason: "checksum"},
+		{Path: "local.txt", Reason: "missing remote"},
+		{Path: "remote.txt", RemoteHash: md5sum("r"), Reason: "missing local"},
+	}
+	if len(mismatches) != len(expected) {
+		t.Fatalf("mismatches: %+v", mismatches)
+	}
+	for i := range expected {
+		if mismatches[i] != expected[i] {
+			t.Errorf("mismatch %d: %+v, expected %+v", i, mismatches[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package objectStorageV1
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunJobs(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		running, max int
+		done         = make(map[int]bool)
+	)
+	err := runJobs(20, 3, func(i int) error {
+		mu.Lock()
+		running++
+		if running > max {
+			max = running
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		done[i] = true
+		mu.Unlock()
+		return nil
+	})
+	if err != nil || len(done) != 20 {
+		t.Errorf("runJobs: %d jobs done, %v", len(done), err)
+	}
+	if max > 3 {
+		t.Errorf("%d concurrent jobs, max 3", max)
+	}
+}
+
+func TestRunJobsNone(t *testing.T) {
+	if err := runJobs(0, 5, func(i int) error { return errors.New("unexpected job") }); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunJobsError(t *testing.T) {
+	failure := errors.New("job 3 failed")
+	var started []int
+	err := runJobs(10, 1, func(i int) error {
+		started = append(started, i)
+		if i == 3 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure {
+		t.Errorf("runJobs error: %v", err)
+	}
+	if len(started) != 4 {
+		t.Errorf("jobs started after the failure: %v", started)
+	}
+}
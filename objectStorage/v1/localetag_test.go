@@ -0,0 +1,38 @@
+package objectStorageV1
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalEtagSlo(t *testing.T) {
+	md5sum := func(s string) string { return fmt.Sprintf("%x", md5.Sum([]byte(s))) }
+	segments := []sloSegment{
+		{Name: "seg/1", Hash: md5sum("abc"), Bytes: 3},
+		{Name: "seg/2", Hash: md5sum("de"), Bytes: 2},
+	}
+	expected := fmt.Sprintf("%x", md5.Sum([]byte(md5sum("abc")+md5sum("de"))))
+
+	tests := []struct {
+		name, content, etag string
+	}{
+		{"same size", "abcde", expected},
+		{"longer", "abcdef", ""},
+		{"shorter", "abcd", ""},
+		{"shorter than the first segment", "ab", ""},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name)
+		if err := ioutil.WriteFile(path, []byte(test.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		etag, err := localEtag(path, segments)
+		if err != nil || etag != test.etag {
+			t.Errorf("%s: localEtag = %q, %v, expected %q", test.name, etag, err, test.etag)
+		}
+	}
+}
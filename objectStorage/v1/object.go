@@ -1,16 +1,124 @@
 package objectStorageV1
 
 import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/Toorop/gopenstack"
 )
 
 // {"hash": "eda9a9889837ac4bc81d6387d92c1bec", "last_modified": "2014-10-27T16:35:40.140480", "bytes": 204800000, "name": "453410c1-dab8-4884-8dc2-af57b33b4a29-00400", "content_type": "application/octet-stream"}
 
-// object represents an openstack object
-type object struct {
-	Name         string                `json:"name"`          // The name of the object
-	Hash         string                `json:"hash"`          // The MD5 checksum value of the object content
-	Bytes        uint64                `json:"bytes"`         // The total number of bytes that are stored for this Object
-	ContentType  string                `json:"content_type"`  // The content type of the object
-	LastModified gopenstack.DateTimeOs `json:"last_modified"` // The date and time when the object was last modified
+// Object represents an openstack object
+// With a delimiter listing, pseudo directories only have Subdir set
+type Object struct {
+	Name              string                `json:"name"`          // The name of the object
+	Hash              string                `json:"hash"`          // The MD5 checksum value of the object content
+	Bytes             uint64                `json:"bytes"`         // The total number of bytes that are stored for this Object
+	ContentType       string                `json:"content_type"`  // The content type of the object
+	ContentTypeParams map[string]string     `json:"-"`             // The parameters of the content type (eg charset)
+	LastModified      gopenstack.DateTimeOs `json:"last_modified"` // The date and time when the object was last modified
+	VersionId         string                `json:"version_id"`    // The version id of the object (versioned listings only)
+	IsLatest          bool                  `json:"is_latest"`     // True if this version is the current one (versioned listings only)
+	SymlinkPath       string                `json:"symlink_path"`  // The path of the symlink target (symlinks only)
+	Subdir            string                `json:"subdir"`        // The pseudo directory (delimiter listings only)
+	SloEtag           string                `json:"slo_etag"`      // The etag of a static large object (listings only)
+	DeleteAt          time.Time             `json:"-"`             // Scheduled deletion date, HEAD only
+	Metadata          map[string]string     `json:"-"`             // Object metadata (X-Object-Meta-*), HEAD only
+}
+
+// UnmarshalJSON decodes a listing entry and parses its content type
+func (o *Object) UnmarshalJSON(data []byte) error {
+	type listingObject Object
+	if err := json.Unmarshal(data, (*listingObject)(o)); err != nil {
+		return err
+	}
+	o.parseContentType()
+	return nil
+}
+
+// IsSubdir returns true if o is a pseudo directory of a delimiter listing
+func (o *Object) IsSubdir() bool {
+	return o.Subdir != ""
+}
+
+// parseContentType splits ContentType into media type and parameters
+func (o *Object) parseContentType() {
+	if o.ContentType == "" {
+		return
+	}
+	if mediaType, params, err := mime.ParseMediaType(o.ContentType); err == nil {
+		o.ContentType = mediaType
+		o.ContentTypeParams = params
+	}
+}
+
+// objectFromHeaders returns an Object populated from HEAD response headers
+func objectFromHeaders(name, account string, headers http.Header) (o Object) {
+	o.Name = name
+	o.Hash = strings.Trim(headers.Get("Etag"), `"`)
+	o.Bytes, _ = strconv.ParseUint(headers.Get("Content-Length"), 10, 64)
+	o.ContentType = headers.Get("Content-Type")
+	o.parseContentType()
+	if lm, err := time.Parse(time.RFC1123, headers.Get("Last-Modified")); err == nil {
+		o.LastModified.Time = lm
+	}
+	o.VersionId = headers.Get("X-Object-Version-Id")
+	if target := headers.Get("X-Symlink-Target"); target != "" {
+		if a := headers.Get("X-Symlink-Target-Account"); a != "" {
+			account = a
+		}
+		o.SymlinkPath = "/v1/" + account + "/" + target
+	}
+	if da := headers.Get("X-Delete-At"); da != "" {
+		o.DeleteAt = parseDeleteAt(da)
+	}
+	o.Metadata = metadataFromHeaders(headers, "X-Object-Meta-")
+	return
+}
+
+// HeadObject returns object informations (symlinks are not followed)
+func (s *Swift) HeadObject(path string) (o Object, err error) {
+	p, err := ParsePath(path)
+	if err != nil {
+		return
+	}
+	if p.Kind() != KindObject {
+		err = gopenstack.ErrNoObjectSpecified
+		return
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: escapePath(p.String()) + "?symlink=get",
+	})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return
+	}
+	if resp.StatusCode == 404 {
+		err = gopenstack.ErrPathNotFound(path)
+		return
+	}
+	return objectFromHeaders(p.Key, s.account(), resp.Headers), nil
+}
+
+// account returns the account of the client endpoint (eg AUTH_xxx)
+func (s *Swift) account() string {
+	u, err := url.Parse(s.client.GetEndpoint())
+	if err != nil {
+		return ""
+	}
+	i := strings.Index(u.Path, "/v1/")
+	if i == -1 {
+		return ""
+	}
+	p, err := ParseFullPath(u.Path[i:])
+	if err != nil {
+		return ""
+	}
+	return p.Account
 }
@@ -3,6 +3,7 @@ package objectStorageV1
 import (
 	"encoding/json"
 	"github.com/Toorop/gopenstack"
+	"net/url"
 	"path"
 	"strconv"
 	"strings"
@@ -11,14 +12,18 @@ import (
 
 // An osPath is a representation of an openstack object storage path
 type osPath struct {
-	client       *gopenstack.Client
-	Name         string
-	Ptype        string                // root, container, object, vfolder
-	Etag         string                `json:"hash"`
-	ContentType  string                `json:"content_type"`
-	Bytes        uint64                `json:"bytes"`
-	Count        uint                  `json:"count"`
-	LastModified gopenstack.DateTimeOs `json:"last_modified"`
+	client        *gopenstack.Client
+	path          Path  // parsed path
+	pathErr       error // parse error of path
+	Name          string
+	Ptype         PathKind              `json:"-"` // root, container, object, vfolder, symlink
+	Etag          string                `json:"hash"`
+	ContentType   string                `json:"content_type"`
+	Bytes         uint64                `json:"bytes"`
+	Count         uint64                `json:"count"`
+	LastModified  gopenstack.DateTimeOs `json:"last_modified"`
+	DeleteAt      time.Time             `json:"-"` // Scheduled deletion date (objects only, zero if none)
+	SymlinkTarget string                `json:"-"` // Target of the symlink (symlinks only)
 }
 
 // NewObjectStoragesPath return an osPath
@@ -33,23 +38,37 @@ func NewOsPath(client *gopenstack.Client, rawPath string) *osPath {
 		rawPath = "/" + rawPath
 	}
 	p.Name = path.Clean(rawPath)
+	p.path, p.pathErr = ParsePath(p.Name)
 	return p
 }
 
+// GetPath returns the parsed path
+func (p *osPath) GetPath() (Path, error) {
+	return p.path, p.pathErr
+}
+
 // GetType returns the type of the "object" behind the path
-// It can be : container, object, vfolder, (more)
-func (p *osPath) GetType() (string, error) {
-	if p.Ptype != "" {
+// It can be : container, object, vfolder, symlink, (more)
+func (p *osPath) GetType() (PathKind, error) {
+	if p.Ptype != KindUnknown {
 		return p.Ptype, nil
 	}
+	if p.pathErr != nil {
+		return KindUnknown, p.pathErr
+	}
+	ressource := p.Name
+	if p.GetPrefix() != "" {
+		// do not follow symlinks
+		ressource += "?symlink=get"
+	}
 	resp, err := p.client.Call(&gopenstack.CallOptions{
 		Method:    "HEAD",
-		Ressource: p.Name,
+		Ressource: ressource,
 	})
 
 	err = resp.HandleErr(err, []int{200, 204, 404})
 	if err != nil {
-		return "", err
+		return KindUnknown, err
 	}
 
 	// If 404 it must be a vfolder or nothing
@@ -57,45 +76,56 @@ func (p *osPath) GetType() (string, error) {
 		// Search vpath in container
 		resp, err := p.client.Call(&gopenstack.CallOptions{
 			Method:    "GET",
-			Ressource: p.GetContainer() + "?format=json",
+			Ressource: url.QueryEscape(p.GetContainer()) + "?format=json&limit=1&prefix=" + url.QueryEscape(p.GetPrefix()),
 		})
 
-		err = resp.HandleErr(err, []int{200, 404})
-
-		if resp.StatusCode == 404 {
-			return "", gopenstack.ErrPathNotFound(p.Name)
+		err = resp.HandleErr(err, []int{200, 204, 404})
+		if err != nil {
+			return KindUnknown, err
 		}
-		var objects []object
+		if resp.StatusCode != 200 || p.GetPrefix() == "" {
+			return KindUnknown, gopenstack.ErrPathNotFound(p.Name)
+		}
+		var objects []Object
 		if err = json.Unmarshal(resp.Body, &objects); err != nil {
-			return "", err
+			return KindUnknown, err
 		}
-		prefix := p.GetPrefix()
-		for _, o := range objects {
-			if strings.HasPrefix(o.Name, prefix) {
-				p.Ptype = "vfolder"
-				return p.Ptype, nil
-			}
+		if len(objects) != 0 {
+			p.Ptype = KindVfolder
+			return p.Ptype, nil
 		}
-		return "", gopenstack.ErrPathNotFound(p.Name)
+		return KindUnknown, gopenstack.ErrPathNotFound(p.Name)
 	}
 
 	// Region root
 	if resp.Headers["X-Account-Container-Count"] != nil {
-		p.Ptype = "root"
+		p.Ptype = KindRoot
 		return p.Ptype, nil
 	}
 
 	// Container
 	if resp.Headers["X-Container-Bytes-Used"] != nil || resp.Headers["X-Container-Object-Count"] != nil {
-		p.Ptype = "container"
+		p.Ptype = KindContainer
+		return p.Ptype, nil
+	}
+	// Symlink
+	if target := resp.Headers.Get("X-Symlink-Target"); target != "" {
+		p.SymlinkTarget, err = url.PathUnescape(target)
+		if err != nil {
+			return KindUnknown, err
+		}
+		p.Ptype = KindSymlink
 		return p.Ptype, nil
 	}
 	// Object
 	if resp.Headers["Etag"] != nil {
-		p.Ptype = "object"
+		if da := resp.Headers.Get("X-Delete-At"); da != "" {
+			p.DeleteAt = parseDeleteAt(da)
+		}
+		p.Ptype = KindObject
 		return p.Ptype, nil
 	}
-	return "", gopenstack.ErrPathNotFound(p.Name)
+	return KindUnknown, gopenstack.ErrPathNotFound(p.Name)
 }
 
 // ListChidren returns children of a givent path
@@ -106,7 +136,7 @@ func (p *osPath) ListChildren() (children []osPath, err error) {
 	}
 
 	switch pathType {
-	case "root":
+	case KindRoot:
 		// List container
 		resp, err := p.client.Call(&gopenstack.CallOptions{
 			Method:    "GET",
@@ -120,10 +150,10 @@ func (p *osPath) ListChildren() (children []osPath, err error) {
 		}
 		// add ptype
 		for k, _ := range children {
-			children[k].Ptype = "container"
+			children[k].Ptype = KindContainer
 		}
 
-	case "container", "vfolder":
+	case KindContainer, KindVfolder:
 		resp, err := p.client.Call(&gopenstack.CallOptions{
 			Method:    "GET",
 			Ressource: p.GetContainer() + "?format=json&prefix=" + p.GetPrefix(),
@@ -173,7 +203,7 @@ func (p *osPath) ListChildren() (children []osPath, err error) {
 			}
 		}
 
-	case "object":
+	case KindObject, KindSymlink:
 		//fmt.Println("object")
 		resp, err := p.client.Call(&gopenstack.CallOptions{
 			Method:    "HEAD",
@@ -191,9 +221,12 @@ func (p *osPath) ListChildren() (children []osPath, err error) {
 		cp.Etag = resp.Headers["Etag"][0]
 		cp.Bytes, _ = strconv.ParseUint(resp.Headers["Content-Length"][0], 10, 64)
 		cp.ContentType = resp.Headers["Content-Type"][0]
+		if da := resp.Headers.Get("X-Delete-At"); da != "" {
+			cp.DeleteAt = parseDeleteAt(da)
+		}
 		children = append(children, cp)
 	default:
-		err = gopenstack.ErrUnsuportedPathType(pathType)
+		err = gopenstack.ErrUnsuportedPathType(pathType.String())
 		return
 
 	}
@@ -201,40 +234,81 @@ func (p *osPath) ListChildren() (children []osPath, err error) {
 }
 
 // GetChildrenObjects return children object of a given path
-func (p *osPath) GetChildrenObjects() (children []object, err error) {
-	resp, err := p.client.Call(&gopenstack.CallOptions{
-		Method:    "GET",
-		Ressource: p.GetContainer() + "?format=json",
-	})
-	err = resp.HandleErr(err, []int{200, 404})
-	if resp.StatusCode == 404 {
-		return children, gopenstack.ErrPathNotFound(p.Name)
-	}
-	var tObjects []object
-	if err = json.Unmarshal(resp.Body, &tObjects); err != nil {
-		return children, err
+// Listing is paginated (using marker) so containers larger than
+// the cluster listing limit are fully returned
+func (p *osPath) GetChildrenObjects() (children []Object, err error) {
+	prefix := p.GetPrefix()
+	marker := ""
+	for {
+		resp, err := p.client.Call(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: p.GetContainer() + "?format=json&prefix=" + url.QueryEscape(prefix) + "&marker=" + url.QueryEscape(marker),
+		})
+		err = resp.HandleErr(err, []int{200, 204, 404})
+		if err != nil {
+			return children, err
+		}
+		if resp.StatusCode == 404 {
+			return children, gopenstack.ErrPathNotFound(p.Name)
+		}
+		if resp.StatusCode == 204 {
+			return children, nil
+		}
+		var tObjects []Object
+		if err = json.Unmarshal(resp.Body, &tObjects); err != nil {
+			return children, err
+		}
+		if len(tObjects) == 0 {
+			return children, nil
+		}
+		children = append(children, tObjects...)
+		marker = tObjects[len(tObjects)-1].Name
 	}
+}
+
+// ListDir returns direct children of a container or vfolder path
+// using a delimiter listing: pseudo directories have only Subdir set
+func (p *osPath) ListDir() (children []Object, err error) {
 	prefix := p.GetPrefix()
-	for _, o := range tObjects {
-		if strings.HasPrefix(o.Name, prefix) {
-			children = append(children, o)
+	marker := ""
+	for {
+		resp, err := p.client.Call(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: url.QueryEscape(p.GetContainer()) + "?format=json&delimiter=/&prefix=" + url.QueryEscape(prefix) + "&marker=" + url.QueryEscape(marker),
+		})
+		if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+			return children, err
+		}
+		if resp.StatusCode == 404 {
+			return children, gopenstack.ErrPathNotFound(p.Name)
+		}
+		if resp.StatusCode == 204 {
+			return children, nil
+		}
+		var page []Object
+		if err = json.Unmarshal(resp.Body, &page); err != nil {
+			return children, err
+		}
+		if len(page) == 0 {
+			return children, nil
+		}
+		children = append(children, page...)
+		if last := page[len(page)-1]; last.IsSubdir() {
+			marker = last.Subdir
+		} else {
+			marker = last.Name
 		}
 	}
-	return
 }
 
 // GetContainer return the container correspondig to a given path
-// If path is rooot return a empty syting
+// If path is root return an empty string
 func (p *osPath) GetContainer() string {
-	return strings.Split(p.Name, "/")[1]
+	return p.path.Container
 }
 
-// GetPrefix return prfix for API query
+// GetPrefix return prefix for API query
 // PATH = /container/prefix...
 func (p *osPath) GetPrefix() string {
-	container := p.GetContainer()
-	if len(p.Name) > len(container)+1 {
-		return p.Name[len(p.GetContainer())+2:] + "/"
-	}
-	return ""
+	return p.path.Prefix()
 }
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Toorop/gopenstack"
@@ -18,16 +22,21 @@ import (
 
 // A Swift is a high-level representation of the openstack object storage service
 type Swift struct {
-	client *gopenstack.Client
+	client         *gopenstack.Client
+	capabilitiesMu sync.Mutex
+	capabilities   *Capabilities // nil until /info is fetched
 }
 
 // NewObjectStoragesPath return an osPath
 func NewSwift(client *gopenstack.Client) *Swift {
-	return &Swift{client}
+	return &Swift{client: client}
 }
 
 // CreateContainer create a container if it doesn't exists
 func (s *Swift) AddContainer(container string) (err error) {
+	if max := s.knownCapabilities().Swift.MaxContainerNameLength; max > 0 && len(container) > max {
+		return gopenstack.ErrNameTooLong(container, max)
+	}
 	resp, err := s.client.Call(&gopenstack.CallOptions{
 		Method:    "HEAD",
 		Ressource: url.QueryEscape(container),
@@ -47,15 +56,14 @@ func (s *Swift) AddContainer(container string) (err error) {
 			Method:    "PUT",
 			Ressource: url.QueryEscape(container),
 		})
-		fmt.Println("resp is: ", resp)
-		fmt.Println("err is: ", err)
+		err = resp.HandleErr(err, []int{201, 202})
 	}
 
 	return
 }
 
 // ListContainers returns containers
-func (s *Swift) ListContainers() (containers []container, err error) {
+func (s *Swift) ListContainers() (containers []Container, err error) {
 	resp, err := s.client.Call(&gopenstack.CallOptions{
 		Method:    "GET",
 		Ressource: "?format=json",
@@ -69,6 +77,13 @@ func (s *Swift) ListContainers() (containers []container, err error) {
 
 // DownloadObject download and save to dest, src object
 func (s *Swift) DownloadObject(src, dest string) error {
+	return s.DownloadObjectWithOptions(src, dest, nil)
+}
+
+// DownloadObjectWithOptions download and save to dest, src object
+// If options.Verify is set, downloaded bytes are checked against the object etag
+// (or the segments etags for static large objects) and dest is removed on mismatch
+func (s *Swift) DownloadObjectWithOptions(src, dest string, options *CopyOptions) error {
 	// Create local folder if needed
 	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
 		return err
@@ -91,12 +106,98 @@ func (s *Swift) DownloadObject(src, dest string) error {
 		return err
 	}
 	defer o.Close()
-	_, err = io.Copy(o, i)
+	if options == nil {
+		options = &CopyOptions{}
+	}
+
+	// verification is done on stored (encrypted) bytes
+	var body io.Reader = i
+	var v *verifier
+	if options.Verify {
+		if v, err = s.newVerifier(src, resp.Headers); err != nil {
+			return err
+		}
+		body = io.TeeReader(body, v)
+	}
+	decoded, err := options.decode(body, resp.Headers)
+	if err != nil {
+		o.Close()
+		os.Remove(dest)
+		return err
+	}
+	defer decoded.Close()
+	if _, err = io.Copy(o, decoded); err == nil && v != nil {
+		err = v.check(src)
+	}
+	if err != nil {
+		o.Close()
+		os.Remove(dest)
+	}
 	return err
 }
 
+// GetObject returns a reader of src object content, the caller must close it
+// If options.KeyProvider is set, encrypted objects are decrypted on the fly
+// (without it, getting an encrypted object returns gopenstack.ErrNoKeyProvider)
+// If options.Decompress is set, compressed objects are decompressed on the fly
+func (s *Swift) GetObject(src string, options *CopyOptions) (io.ReadCloser, error) {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:             "GET",
+		Ressource:          escapePath(src),
+		ReturnBodyAsReader: true,
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return nil, err
+	}
+	decoded, err := options.decode(resp.BodyReader, resp.Headers)
+	if err != nil {
+		resp.BodyReader.Close()
+		return nil, err
+	}
+	return &multiReadCloser{decoded, []io.Closer{decoded, resp.BodyReader}}, nil
+}
+
+// decode returns body of an object decrypted and decompressed
+// according to options and object headers
+// Encrypted objects can not be read without a KeyProvider
+func (o *CopyOptions) decode(body io.Reader, headers http.Header) (io.ReadCloser, error) {
+	if isEncrypted(headers) {
+		if o == nil || o.KeyProvider == nil {
+			return nil, gopenstack.ErrNoKeyProvider
+		}
+		c, err := objectCipherFromHeaders(headers, o.KeyProvider)
+		if err != nil {
+			return nil, err
+		}
+		body = c.newDecryptReader(body)
+	}
+	if o == nil {
+		return ioutil.NopCloser(body), nil
+	}
+	if algorithm := compressionOf(headers); o.Decompress && algorithm != "" {
+		return newDecompressReader(algorithm, body)
+	}
+	return ioutil.NopCloser(body), nil
+}
+
+// CopyOptions represents options for DownloadPath and Copy
+type CopyOptions struct {
+	PreserveSymlinks bool        // Preserve symlinks instead of following them
+	Verify           bool        // Check integrity of transferred data
+	KeyProvider      KeyProvider // Decrypt client side encrypted objects
+	Decompress       bool        // Decompress objects uploaded with compression
+}
+
 // GetAndStore recursively gets objects from srcPath and write them under destPath
 func (s *Swift) DownloadPath(srcPath, destPath string) error {
+	return s.DownloadPathWithOptions(srcPath, destPath, nil)
+}
+
+// DownloadPathWithOptions recursively gets objects from srcPath and write them under destPath
+// If options.PreserveSymlinks is set, swift symlinks are created as local symlinks
+// If options.Verify is set, a *gopenstack.ChecksumMismatchError is returned for
+// the first corrupted object (its local file is removed) and the download stops
+func (s *Swift) DownloadPathWithOptions(srcPath, destPath string, options *CopyOptions) error {
 
 	// we must have a container specified
 	if srcPath == "" || srcPath == "/" {
@@ -117,7 +218,8 @@ func (s *Swift) DownloadPath(srcPath, destPath string) error {
 	hasTrailingSlash := false
 	container := dPath.GetContainer()
 
-	// clean paths
+	// clean paths (prefix below is computed from a path without leading slash)
+	srcPath = strings.TrimPrefix(srcPath, "/")
 	if strings.HasSuffix(srcPath, "/") {
 		srcPath = srcPath[0 : len(srcPath)-1]
 		hasTrailingSlash = true
@@ -127,102 +229,149 @@ func (s *Swift) DownloadPath(srcPath, destPath string) error {
 	}
 
 	// is container
-	isContainer := pathType == "container"
+	isContainer := pathType == KindContainer
 	prefix := ""
 	if !isContainer {
 		prefix = srcPath[len(container):]
 	}
 	pPrefix := strings.Split(prefix, "/")
 
+	// localDest returns the local path of object name
+	localDest := func(name string) string {
+		dest := destPath + "/"
+		if !hasTrailingSlash {
+			if isContainer {
+				dest += container + "/" + name
+			} else {
+				dest += pPrefix[len(pPrefix)-1] + "/" + name[len(prefix):]
+			}
+		} else {
+			if isContainer {
+				dest += name
+			} else {
+				dest += name[len(prefix):]
+			}
+		}
+		return dest
+	}
+
+	// localDestOf returns the local path of object container/name if it is part of the download
+	localDestOf := func(c, name string) (string, bool) {
+		if c != container || (!isContainer && !strings.HasPrefix(name, prefix[1:]+"/")) {
+			return "", false
+		}
+		return localDest(name), true
+	}
+
 	objectsToDownload, err := dPath.GetChildrenObjects()
 	if err != nil {
 		return err
 	}
 
-	// Upload files
-	chanDone := make(chan bool)
-	chanAJobIsDone := make(chan bool)
-	chanThreadCount := make(chan int)
-	threadsCount := 0
-	remainingJobs := len(objectsToDownload)
+	// Download files (5 concurrent downloads)
+	return runJobs(len(objectsToDownload), 5, func(i int) error {
+		o := objectsToDownload[i]
+		src := container + "/" + o.Name
+		dest := localDest(o.Name)
+		if options != nil && options.PreserveSymlinks && o.SymlinkPath != "" {
+			return s.downloadSymlink(src, dest, o.SymlinkPath, localDestOf)
+		}
+		return s.DownloadObjectWithOptions(src, dest, options)
+	})
+}
+
+// PutOptions represents upload options
+type PutOptions struct {
+	DeleteAt    time.Time         // Date at which the object will be deleted (X-Delete-At)
+	DeleteAfter time.Duration     // TTL of the object (X-Delete-After)
+	Headers     map[string]string // Extra headers
+
+	// PreserveSymlinks makes Put upload local symlinks pointing
+	// inside the uploaded tree as swift symlinks
+	PreserveSymlinks bool
+
+	// Verify checks the etag returned by the cluster
+	Verify bool
+
+	// KeyProvider enables client side encryption, content is
+	// encrypted with a data key wrapped by KeyProvider
+	KeyProvider KeyProvider
+
+	// Compression enables compression (before encryption) of matching files
+	Compression *Compression
+}
 
-	// Count concurrent threads
-	go func() {
-		for {
-			threadsCount += <-chanThreadCount
+// headers returns headers corresponding to options
+func (o *PutOptions) headers() map[string]string {
+	headers := make(map[string]string)
+	if o == nil {
+		return headers
+	}
+	for k, v := range o.Headers {
+		headers[k] = v
+	}
+	if !o.DeleteAt.IsZero() {
+		headers["X-Delete-At"] = strconv.FormatInt(o.DeleteAt.Unix(), 10)
+	}
+	if o.DeleteAfter > 0 {
+		// rounded up, a sub-second TTL must not become an immediate deletion
+		ttl := (o.DeleteAfter + time.Second - 1) / time.Second
+		headers["X-Delete-After"] = strconv.FormatInt(int64(ttl), 10)
+	}
+	return headers
+}
+
+// encode returns content of object name read from src compressed and encrypted
+// according to options, and adds corresponding headers. Returned reader must be closed.
+// sum is the plain content md5 if known, stored as an HMAC if content is encrypted.
+func (o *PutOptions) encode(name string, src io.Reader, headers map[string]string, sum string) (io.ReadCloser, error) {
+	payload := ioutil.NopCloser(src)
+	if o == nil {
+		return payload, nil
+	}
+	if o.Compression.match(name, headers["Content-Type"]) {
+		compressed, err := newCompressReader(o.Compression.Algorithm, src)
+		if err != nil {
+			return nil, err
 		}
-	}()
-
-	// Update remainingJobs and send "all jobs are done" signal
-	go func() {
-		for {
-			<-chanAJobIsDone
-			remainingJobs--
-			if remainingJobs < 1 {
-				chanDone <- true
-				break
-			}
+		payload = compressed
+		headers[hdrCompression] = o.Compression.Algorithm
+		if o.KeyProvider == nil {
+			headers[hdrContentEncode] = o.Compression.Algorithm
 		}
-	}()
-	exitAsap := false
-	for _, o := range objectsToDownload {
-
-		for {
-			if threadsCount < 5 {
-				threadsCount++
-				break
-			}
-			time.Sleep(1 * time.Second)
+	}
+	if o.KeyProvider != nil {
+		c, cryptoHeaders, err := newObjectCipher(o.KeyProvider)
+		if err != nil {
+			payload.Close()
+			return nil, err
 		}
-		if exitAsap {
-			// Wait for running process to finish their task
-			for {
-				if threadsCount == 1 {
-					break
-				}
-				time.Sleep(1 * time.Second)
-			}
-			break
+		for k, v := range cryptoHeaders {
+			headers[k] = v
 		}
-
-		go func(o object) {
-			src := container + "/" + o.Name
-			dest := destPath + "/"
-			if !hasTrailingSlash {
-				if isContainer {
-					dest += container + "/" + o.Name
-				} else {
-					dest += pPrefix[len(pPrefix)-1] + "/" + o.Name[len(prefix):]
-				}
-			} else {
-				if isContainer {
-					dest += o.Name
-				} else {
-					dest += o.Name[len(prefix):]
-				}
-			}
-
-			//fmt.Println(o)
-			//fmt.Println(o.Name, src+" -> "+dest)
-			err = s.DownloadObject(src, dest)
-			if err != nil {
-				threadsCount--
-				exitAsap = true
-				chanDone <- true
-				return
-			}
-			threadsCount--
-			chanAJobIsDone <- true
-		}(o)
+		if sum != "" {
+			headers[hdrCryptoPlainMac] = c.plainMac(sum)
+		}
+		payload = &multiReadCloser{c.newEncryptReader(payload), []io.Closer{payload}}
 	}
-	// Waiting for all jobs to finish
-	<-chanDone
-	return nil
+	return payload, nil
+}
+
+// transformed returns true if upload headers denote compressed or encrypted content
+func transformed(headers map[string]string) bool {
+	return headers[hdrCompression] != "" || isEncryptedHeaders(headers)
 }
 
 // Put upload a file to storage
 // If the file exists (with the same etag) PutFile does not reupload it
 func (s *Swift) PutFile(src, dest string) (err error) {
+	return s.PutFileWithOptions(src, dest, nil)
+}
+
+// PutFileWithOptions upload a file to storage using options
+// If the file exists (with the same etag) it is not reuploaded but its
+// expiration and options.Headers are updated (POST) if needed
+func (s *Swift) PutFileWithOptions(src, dest string, options *PutOptions) (err error) {
 	//fmt.Println(src + "->" + dest)
 
 	// we must have a conatainer specified
@@ -251,6 +400,14 @@ func (s *Swift) PutFile(src, dest string) (err error) {
 	}
 	contentLenght := strconv.FormatInt(stats.Size(), 10)
 
+	// Fail early on cluster limits
+	if err = s.checkObjectSize(stats.Size()); err != nil {
+		return
+	}
+	if err = s.checkObjectName(dest[strings.Index(dest[1:], "/")+2:]); err != nil {
+		return
+	}
+
 	// ETag (md5 sum)
 	md5Reader, _ := os.Open(src)
 	h := md5.New()
@@ -259,39 +416,125 @@ func (s *Swift) PutFile(src, dest string) (err error) {
 	md5Reader.Close()
 
 	// Do a Head request to see if the object already exists
+	// (a failed HEAD aborts the upload, an object without Etag is uploaded)
 	resp, err := s.client.Call(&gopenstack.CallOptions{
 		Method:    "HEAD",
 		Ressource: escapePath(dest),
 	})
 
-	err = resp.HandleErr(err, []int{200, 204, 404})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return
+	}
 
-	if resp.StatusCode != 404 && resp.Headers["Etag"][0] == etag {
+	// Compare with plain content md5 if content is transformed
+	unchanged := resp.Headers.Get("Etag") == etag
+	if options != nil && options.KeyProvider != nil {
+		unchanged = isEncryptedCopy(resp.Headers, options.KeyProvider, etag)
+	} else if options != nil && options.Compression.match(dest, options.Headers["Content-Type"]) {
+		unchanged = resp.Headers.Get(hdrOriginalMd5) == etag
+	}
+	if resp.StatusCode != 404 && unchanged {
+		if headers := options.headers(); len(headers) > 0 {
+			err = s.postObject(dest, headers)
+		}
 		return
 	}
 
 	// Headers
-	headers := make(map[string]string)
+	headers := options.headers()
 	headers["Content-Length"] = contentLenght
 	headers["Etag"] = etag
 
+	payload, err := options.encode(dest, bodyReader, headers, etag)
+	if err != nil {
+		return
+	}
+	defer payload.Close()
+	var sent hash.Hash
+	if transformed(headers) {
+		// plain content information is not stored in clear for encrypted objects
+		if !isEncryptedHeaders(headers) {
+			headers[hdrOriginalSize] = contentLenght
+			headers[hdrOriginalMd5] = etag
+		}
+		// stored content size and etag are only known once sent
+		delete(headers, "Content-Length")
+		delete(headers, "Etag")
+		sent = md5.New()
+		payload = &multiReadCloser{io.TeeReader(payload, sent), []io.Closer{payload}}
+	}
+
 	resp, err = s.client.Call(&gopenstack.CallOptions{
 		Method:    "PUT",
 		Ressource: dest + "?format=json",
-		Payload:   bodyReader,
+		Payload:   payload,
 		Headers:   headers,
 	})
-	err = resp.HandleErr(err, []int{200, 201})
+	if err = resp.HandleErr(err, []int{200, 201}); err != nil {
+		return
+	}
+	if options != nil && options.Verify {
+		expected := etag
+		if sent != nil {
+			expected = fmt.Sprintf("%x", sent.Sum(nil))
+		}
+		if got := strings.Trim(resp.Headers.Get("Etag"), `"`); got != expected {
+			err = gopenstack.ErrChecksumMismatch(dest, expected, got)
+		}
+	}
 	return
 }
 
+// PutObject uploads content read from r to dest (streaming, size does not need to be known)
+// If options.KeyProvider is set, content is encrypted on the fly
+// If options.Compression matches dest, content is compressed on the fly
+func (s *Swift) PutObject(dest string, r io.Reader, options *PutOptions) error {
+	if strings.Count(dest, "/") < 2 {
+		return gopenstack.ErrNoContainerSpecified
+	}
+	if err := s.checkObjectName(dest[strings.Index(dest[1:], "/")+2:]); err != nil {
+		return err
+	}
+
+	headers := options.headers()
+	payload, err := options.encode(dest, r, headers, "")
+	if err != nil {
+		return err
+	}
+	defer payload.Close()
+	sent := md5.New()
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: escapePath(dest),
+		Payload:   io.TeeReader(payload, sent),
+		Headers:   headers,
+	})
+	if err = resp.HandleErr(err, []int{200, 201}); err != nil {
+		return err
+	}
+	if options != nil && options.Verify {
+		expected := fmt.Sprintf("%x", sent.Sum(nil))
+		if got := strings.Trim(resp.Headers.Get("Etag"), `"`); got != expected {
+			return gopenstack.ErrChecksumMismatch(dest, expected, got)
+		}
+	}
+	return nil
+}
+
 // Put recursively upload files under srcPath to destPath
+// Files are uploaded under destPath/<base name of srcPath>, eg Put("/tmp/tree", "/c/dir")
+// uploads /tmp/tree/a.txt to /c/dir/tree/a.txt
 func (s *Swift) Put(srcPath, destPath string) error {
+	return s.PutWithOptions(srcPath, destPath, nil)
+}
+
+// PutWithOptions recursively upload files under srcPath to destPath
+// options are applied to every uploaded file (eg a TTL for the whole tree)
+func (s *Swift) PutWithOptions(srcPath, destPath string, options *PutOptions) error {
 	srcPath, err := filepath.Abs(filepath.Clean(srcPath))
 	if err != nil {
 		return err
 	}
-	fmt.Println(srcPath + " -> " + destPath)
 	if strings.HasSuffix(destPath, "/") {
 		destPath = destPath[:len(destPath)-1]
 	}
@@ -316,77 +559,17 @@ func (s *Swift) Put(srcPath, destPath string) error {
 		return err
 	}
 
-	// Upload files
-	chanDone := make(chan bool)
-	chanAJobIsDone := make(chan bool)
-	chanThreadCount := make(chan int)
-	threadsCount := 0
-	remainingJobs := len(childrenPaths)
-
-	// Count concurrent threads
-	go func() {
-		for {
-			threadsCount += <-chanThreadCount
-		}
-	}()
-
-	// Update remainingJobs and send "all jobs are done" signal
-	go func() {
-		for {
-			<-chanAJobIsDone
-			remainingJobs--
-			if remainingJobs < 1 {
-				chanDone <- true
-				break
-			}
-		}
-	}()
-
-	// PUT
+	// PUT (5 concurrent uploads)
 	ps := strings.Split(srcPath, "/")
-	exitAsap := false
-	for _, p := range childrenPaths {
-		for {
-			if threadsCount < 5 {
-				threadsCount++
-				break
-			}
-			time.Sleep(1 * time.Second)
+	return runJobs(len(childrenPaths), 5, func(i int) error {
+		p := childrenPaths[i]
+		destination := destPath + "/" + ps[len(ps)-1]
+		destination += p[len(srcPath):]
+		if target, ok := remoteSymlinkTarget(p, srcPath, destPath+"/"+ps[len(ps)-1], options); ok {
+			return s.AddSymlink(destination, target)
 		}
-		if exitAsap {
-			// Wait for running process to finish their task
-			for {
-				if threadsCount == 1 {
-					break
-				}
-				time.Sleep(1 * time.Second)
-			}
-			break
-		}
-		go func(p string) {
-
-			destination := "/"
-
-			if !strings.HasSuffix(srcPath, "/") {
-				destination += ps[len(ps)-1]
-			}
-
-			destination += p[len(srcPath):]
-
-			err = s.PutFile(p, destination)
-			if err != nil {
-				threadsCount--
-				exitAsap = true
-				chanDone <- true
-				return
-			}
-			threadsCount--
-			chanAJobIsDone <- true
-		}(p)
-	}
-	// Waiting for all jobs to finish
-	<-chanDone
-	return err
+		return s.PutFileWithOptions(p, destination, options)
+	})
 }
 
 // Copy recursively copies srcPath to destPath
@@ -395,6 +578,11 @@ func (s *Swift) Put(srcPath, destPath string) error {
 // remote path to local path
 // remote path to remote path (not yet)
 func (s *Swift) Copy(srcPath, destPath string) error {
+	return s.CopyWithOptions(srcPath, destPath, nil)
+}
+
+// CopyWithOptions recursively copies srcPath to destPath using options
+func (s *Swift) CopyWithOptions(srcPath, destPath string, options *CopyOptions) error {
 	srcIsLocal := true
 	destIsLocal := true
 	if _, err := os.Stat(srcPath); err != nil {
@@ -406,10 +594,16 @@ func (s *Swift) Copy(srcPath, destPath string) error {
 	// Do copy
 	if srcIsLocal && !destIsLocal {
 		//fmt.Println("src is local, dest is remote")
-		return s.Put(srcPath, destPath)
+		putOptions := &PutOptions{}
+		if options != nil {
+			putOptions.PreserveSymlinks = options.PreserveSymlinks
+			putOptions.Verify = options.Verify
+			putOptions.KeyProvider = options.KeyProvider
+		}
+		return s.PutWithOptions(srcPath, destPath, putOptions)
 	} else if !srcIsLocal && destIsLocal {
 		//fmt.Println("src is remote, dest is local")
-		return s.DownloadPath(srcPath, destPath)
+		return s.DownloadPathWithOptions(srcPath, destPath, options)
 	} else if !srcIsLocal && !destIsLocal {
 		return errors.New("Not implemented yet")
 	} else {
@@ -448,14 +642,14 @@ func (s *Swift) DeletePath(path string) error {
 	}
 
 	switch pathType {
-	case "object":
+	case KindObject, KindSymlink:
 		objectToremovePaths = append(objectToremovePaths, path)
-	case "container", "vfolder":
+	case KindContainer, KindVfolder:
 		objectsToRemove, err := dPath.GetChildrenObjects()
 		if err != nil {
 			return err
 		}
-		if pathType == "container" {
+		if pathType == KindContainer {
 			for _, o := range objectsToRemove {
 				objectToremovePaths = append(objectToremovePaths, path+"/"+o.Name)
 			}
@@ -472,57 +666,20 @@ func (s *Swift) DeletePath(path string) error {
 			}
 		}
 	default:
-		err = gopenstack.ErrUnsuportedPathType(pathType)
+		err = gopenstack.ErrUnsuportedPathType(pathType.String())
 		return err
 	}
 
 	// Remove objects
-	chanDone := make(chan bool)
-	chanAJobIsDone := make(chan bool)
-	chanThreadCount := make(chan int)
-	threadsCount := 0
-	remainingJobs := len(objectToremovePaths)
-
-	// Count concurrent threads
-	go func() {
-		for {
-			threadsCount += <-chanThreadCount
-		}
-	}()
-
-	// Update remainingJobs and send "all jobs are done"  signal
-	go func() {
-		for {
-			<-chanAJobIsDone
-			remainingJobs--
-			if remainingJobs < 1 {
-				chanDone <- true
-				break
-			}
-		}
-	}()
-
-	// Delete
 	if len(objectToremovePaths) > 0 {
-		for _, p := range objectToremovePaths {
-			for {
-				if threadsCount < 10 {
-					threadsCount++
-					break
-				}
-				time.Sleep(1 * time.Second)
-			}
-			go func(path string) {
-				err = s.DeleteObject(path)
-				if err != nil {
-					chanDone <- true
-				}
-				threadsCount--
-				chanAJobIsDone <- true
-			}(p)
+		if max := s.getBulkDeleteMaxPaths(); max > 0 {
+			err = s.bulkDelete(objectToremovePaths, max, &BulkResult{})
+		} else {
+			err = s.deleteObjects(objectToremovePaths)
+		}
+		if err != nil {
+			return err
 		}
-		// Waiting for all jobs
-		<-chanDone
 	}
 	// remove container if needed
 	if len(containerToRemove) != 0 {
@@ -531,6 +688,53 @@ func (s *Swift) DeletePath(path string) error {
 	return err
 }
 
+// deleteObjects removes objects one by one (10 concurrent requests)
+func (s *Swift) deleteObjects(paths []string) error {
+	return runJobs(len(paths), 10, func(i int) error {
+		return s.DeleteObject(paths[i])
+	})
+}
+
+// runJobs runs job(0) to job(n-1) with at most workers concurrent jobs
+// No job is started after a failure, the first error is returned
+// once running jobs are finished
+func runJobs(n, workers int, job func(i int) error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	sem := make(chan struct{}, workers)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		if failed() {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := job(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return firstErr
+}
+
 // Helpers
 // escapePath returns url.escaped path
 func escapePath(path string) string {
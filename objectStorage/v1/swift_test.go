@@ -0,0 +1,346 @@
+package objectStorageV1_test
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+	"github.com/Toorop/gopenstack/objectStorage/v1/swifttest"
+)
+
+// newTestSwift starts a swifttest server and returns it with a connected Swift and client
+func newTestSwift(t *testing.T) (*swifttest.Server, *objectStorageV1.Swift, *gopenstack.Client) {
+	srv := swifttest.NewServer()
+	t.Cleanup(srv.Close)
+	keyring, err := srv.Keyring()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := objectStorageV1.NewClient(keyring, srv.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, objectStorageV1.NewSwift(client), client
+}
+
+// putObjects creates container c with objects (name: content)
+func putObjects(t *testing.T, s *objectStorageV1.Swift, objects map[string]string) {
+	if err := s.AddContainer("c"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range objects {
+		if err := s.PutObject("/c/"+name, strings.NewReader(content), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// writeFiles creates files (relative path: content) under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// md5sum returns the hex md5 of content
+func md5sum(content string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+}
+
+// names returns names (or subdirs) of objects
+func names(objects []objectStorageV1.Object) []string {
+	n := []string{}
+	for _, o := range objects {
+		if o.IsSubdir() {
+			n = append(n, o.Subdir)
+		} else {
+			n = append(n, o.Name)
+		}
+	}
+	return n
+}
+
+func TestKeystoneCatalog(t *testing.T) {
+	srv := swifttest.NewServer()
+	defer srv.Close()
+	keyring, err := srv.Keyring()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyring.XAuthHeaderToken != srv.Token {
+		t.Errorf("token %q, expected %q", keyring.XAuthHeaderToken, srv.Token)
+	}
+	endpoint, err := keyring.GetEndpointUrl("object-store", srv.Region)
+	if err != nil || endpoint != srv.Endpoint() {
+		t.Errorf("endpoint %q (%v), expected %q", endpoint, err, srv.Endpoint())
+	}
+	if _, err = keyring.GetEndpointUrl("object-store", "OtherRegion"); err == nil {
+		t.Error("endpoint found in an unknown region")
+	}
+}
+
+func TestContainerCRUD(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	if err := s.AddContainer("c"); err != nil {
+		t.Fatal(err)
+	}
+	// adding an existing container is a no-op
+	if err := s.AddContainer("c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutObject("/c/o", strings.NewReader("12345"), nil); err != nil {
+		t.Fatal(err)
+	}
+	containers, err := s.ListContainers()
+	if err != nil || len(containers) != 1 || containers[0].Name != "c" || containers[0].Count != 1 || containers[0].Bytes != 5 {
+		t.Fatalf("ListContainers: %+v, %v", containers, err)
+	}
+	c, err := s.HeadContainer("c")
+	if err != nil || c.Count != 1 || c.Bytes != 5 || c.StoragePolicy != "Policy-0" || c.LastModified.IsZero() {
+		t.Errorf("HeadContainer: %+v, %v", c, err)
+	}
+	if err = s.DeletePath("/c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.HeadContainer("c"); err != gopenstack.ErrContainerNotFound {
+		t.Errorf("HeadContainer of a deleted container: %v", err)
+	}
+}
+
+func TestObjectCRUD(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	content := "hello swift"
+	err := s.PutObject("/c/dir/hello.txt", strings.NewReader(content), &objectStorageV1.PutOptions{
+		Headers: map[string]string{"Content-Type": "text/plain; charset=utf-8", "X-Object-Meta-Color": "blue"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := s.HeadObject("/c/dir/hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Name != "dir/hello.txt" || o.Hash != md5sum(content) || o.Bytes != uint64(len(content)) {
+		t.Errorf("HeadObject: name %q, hash %q, bytes %d", o.Name, o.Hash, o.Bytes)
+	}
+	if o.ContentType != "text/plain" || o.ContentTypeParams["charset"] != "utf-8" {
+		t.Errorf("HeadObject: content type %q %v", o.ContentType, o.ContentTypeParams)
+	}
+	if o.Metadata["Color"] != "blue" || o.LastModified.IsZero() {
+		t.Errorf("HeadObject: metadata %v, last modified %v", o.Metadata, o.LastModified)
+	}
+
+	r, err := s.GetObject("/c/dir/hello.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != content {
+		t.Errorf("GetObject: %q, %v", data, err)
+	}
+
+	if err = s.DeleteObject("/c/dir/hello.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.HeadObject("/c/dir/hello.txt"); err == nil {
+		t.Error("HeadObject of a deleted object succeeded")
+	}
+	if err = s.DeleteObject("/c/dir/hello.txt"); err == nil {
+		t.Error("DeleteObject of a deleted object succeeded")
+	}
+}
+
+func TestPutFileEtag(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"f.txt": "content"})
+	src := filepath.Join(dir, "f.txt")
+
+	if err := s.PutFileWithOptions(src, "/c/f.txt", &objectStorageV1.PutOptions{Verify: true}); err != nil {
+		t.Fatal(err)
+	}
+	o, err := s.HeadObject("/c/f.txt")
+	if err != nil || o.Hash != md5sum("content") {
+		t.Fatalf("HeadObject: %q, %v", o.Hash, err)
+	}
+
+	// unchanged file: no upload
+	srv.ResetRequests()
+	if err = s.PutFile(src, "/c/f.txt"); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == "PUT" {
+			t.Errorf("unchanged file uploaded again")
+		}
+	}
+
+	// changed file: new upload
+	writeFiles(t, dir, map[string]string{"f.txt": "new content"})
+	if err = s.PutFile(src, "/c/f.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if o, err = s.HeadObject("/c/f.txt"); err != nil || o.Hash != md5sum("new content") {
+		t.Errorf("HeadObject after change: %q, %v", o.Hash, err)
+	}
+}
+
+func TestListing(t *testing.T) {
+	_, s, client := newTestSwift(t)
+	putObjects(t, s, map[string]string{"a/1": "1", "a/2": "22", "b": "333", "c/d/e": "4444"})
+
+	objects, err := objectStorageV1.NewOsPath(client, "/c").GetChildrenObjects()
+	if got := strings.Join(names(objects), ","); err != nil || got != "a/1,a/2,b,c/d/e" {
+		t.Errorf("container listing: %s, %v", got, err)
+	}
+	if len(objects) == 4 && (objects[2].Hash != md5sum("333") || objects[2].Bytes != 3 || objects[2].LastModified.IsZero()) {
+		t.Errorf("listing entry: %+v", objects[2])
+	}
+
+	// prefix
+	objects, err = objectStorageV1.NewOsPath(client, "/c/a").GetChildrenObjects()
+	if got := strings.Join(names(objects), ","); err != nil || got != "a/1,a/2" {
+		t.Errorf("prefix listing: %s, %v", got, err)
+	}
+
+	// delimiter
+	objects, err = objectStorageV1.NewOsPath(client, "/c").ListDir()
+	if got := strings.Join(names(objects), ","); err != nil || got != "a/,b,c/" {
+		t.Errorf("delimiter listing: %s, %v", got, err)
+	}
+	objects, err = objectStorageV1.NewOsPath(client, "/c/c").ListDir()
+	if got := strings.Join(names(objects), ","); err != nil || got != "c/d/" {
+		t.Errorf("delimiter listing with prefix: %s, %v", got, err)
+	}
+
+	// missing container
+	if _, err = objectStorageV1.NewOsPath(client, "/missing").GetChildrenObjects(); err == nil {
+		t.Error("listing of a missing container succeeded")
+	}
+}
+
+func TestListingMarker(t *testing.T) {
+	srv, s, client := newTestSwift(t)
+	srv.ListingLimit = 2
+	objects := map[string]string{}
+	for i := 0; i < 5; i++ {
+		objects[fmt.Sprintf("dir/%d", i)] = "x"
+		objects[fmt.Sprintf("other/%d", i)] = "x"
+	}
+	putObjects(t, s, objects)
+
+	srv.ResetRequests()
+	listed, err := objectStorageV1.NewOsPath(client, "/c/dir").GetChildrenObjects()
+	if got := strings.Join(names(listed), ","); err != nil || got != "dir/0,dir/1,dir/2,dir/3,dir/4" {
+		t.Errorf("paginated listing: %s, %v", got, err)
+	}
+	markers := []string{}
+	for _, r := range srv.Requests() {
+		if q, _ := url.ParseQuery(r.Query); r.Method == "GET" && q.Get("marker") != "" {
+			markers = append(markers, q.Get("marker"))
+		}
+	}
+	if got := strings.Join(markers, ","); got != "dir/1,dir/3,dir/4" {
+		t.Errorf("markers: %s", got)
+	}
+
+	// delimiter listing continues after the last subdir
+	listed, err = objectStorageV1.NewOsPath(client, "/c").ListDir()
+	if got := strings.Join(names(listed), ","); err != nil || got != "dir/,other/" {
+		t.Errorf("paginated delimiter listing: %s, %v", got, err)
+	}
+
+	containers, err := s.ListContainers()
+	if err != nil || len(containers) != 1 {
+		t.Errorf("ListContainers: %+v, %v", containers, err)
+	}
+}
+
+func TestPutTree(t *testing.T) {
+	_, s, client := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	files := map[string]string{"a.txt": "a", "sub/b.txt": "b", "sub/deep/c.txt": "c"}
+	writeFiles(t, filepath.Join(dir, "tree"), files)
+
+	if err := s.Put(filepath.Join(dir, "tree"), "/c"); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := objectStorageV1.NewOsPath(client, "/c").GetChildrenObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := names(objects)
+	sort.Strings(got)
+	if strings.Join(got, ",") != "tree/a.txt,tree/sub/b.txt,tree/sub/deep/c.txt" {
+		t.Errorf("uploaded objects: %v", got)
+	}
+
+	dest := t.TempDir()
+	if err = s.DownloadPath("/c/tree", dest); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dest, "tree", filepath.FromSlash(name)))
+		if err != nil || string(data) != content {
+			t.Errorf("%s: %q, %v", name, data, err)
+		}
+	}
+}
+
+func TestPutDestination(t *testing.T) {
+	_, s, client := newTestSwift(t)
+	putObjects(t, s, nil)
+	dir := t.TempDir()
+	writeFiles(t, filepath.Join(dir, "tree"), map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+
+	for _, dest := range []string{"/c/dir", "/c/dir/"} {
+		if err := s.Put(filepath.Join(dir, "tree"), dest); err != nil {
+			t.Fatal(err)
+		}
+		objects, err := objectStorageV1.NewOsPath(client, "/c").GetChildrenObjects()
+		if got := strings.Join(names(objects), ","); err != nil || got != "dir/tree/a.txt,dir/tree/sub/b.txt" {
+			t.Errorf("Put to %s: %s, %v", dest, got, err)
+		}
+	}
+
+	if err := s.Put(filepath.Join(dir, "tree"), "/"); err != gopenstack.ErrNoContainerSpecified {
+		t.Errorf("Put without container: %v", err)
+	}
+}
+
+func TestDownloadPathLeadingSlash(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"dir/a.txt": "a", "dir/sub/b.txt": "b"})
+	// with a trailing slash the content of dir is downloaded
+	for src, dir := range map[string]string{"/c/dir": "dir", "c/dir": "dir", "/c/dir/": ""} {
+		dest := t.TempDir()
+		if err := s.DownloadPath(src, dest); err != nil {
+			t.Fatalf("DownloadPath(%q): %v", src, err)
+		}
+		for name, content := range map[string]string{"a.txt": "a", "sub/b.txt": "b"} {
+			path := filepath.Join(dest, dir, filepath.FromSlash(name))
+			if data, err := ioutil.ReadFile(path); err != nil || string(data) != content {
+				t.Errorf("DownloadPath(%q): %s: %q, %v", src, name, data, err)
+			}
+		}
+	}
+}
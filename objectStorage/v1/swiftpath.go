@@ -0,0 +1,189 @@
+package objectStorageV1
+
+import (
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// Default swift name limits (used to validate paths)
+const (
+	maxContainerNameLength = 256
+	maxObjectNameLength    = 1024
+)
+
+// PathKind represents the kind of "object" behind a swift path
+type PathKind int
+
+const (
+	KindUnknown PathKind = iota
+	KindRoot
+	KindContainer
+	KindObject
+	KindVfolder
+	KindSymlink
+)
+
+// String returns the name of the kind
+func (k PathKind) String() string {
+	switch k {
+	case KindRoot:
+		return "root"
+	case KindContainer:
+		return "container"
+	case KindObject:
+		return "object"
+	case KindVfolder:
+		return "vfolder"
+	case KindSymlink:
+		return "symlink"
+	}
+	return "unknown"
+}
+
+// Path represents a parsed swift path: /container/key
+// Account is only set for full paths (/v1/account/container/key)
+type Path struct {
+	Account   string
+	Container string
+	Key       string // The object key (or vfolder prefix), it may contain /
+}
+
+// ParsePath parses a path relative to the account: /container/key
+func ParsePath(raw string) (Path, error) {
+	var p Path
+	if strings.ContainsRune(raw, 0) {
+		return p, gopenstack.ErrInvalidPath(raw, "null character")
+	}
+	trimmed := strings.TrimPrefix(raw, "/")
+	if trimmed == "" {
+		return p, nil
+	}
+	parts := strings.SplitN(trimmed, "/", 2)
+	p.Container = parts[0]
+	if len(parts) == 2 {
+		p.Key = strings.TrimSuffix(parts[1], "/")
+	}
+	return p, p.validate(raw)
+}
+
+// ParseFullPath parses a path including API version and account: /v1/account/container/key
+func ParseFullPath(raw string) (Path, error) {
+	parts := strings.SplitN(strings.TrimPrefix(raw, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] != "v1" || parts[1] == "" {
+		return Path{}, gopenstack.ErrInvalidPath(raw, "expected /v1/account/...")
+	}
+	rest := ""
+	if len(parts) == 3 {
+		rest = parts[2]
+	}
+	p, err := ParsePath(rest)
+	if err != nil {
+		return p, gopenstack.ErrInvalidPath(raw, err.(*gopenstack.InvalidPathError).Reason)
+	}
+	p.Account = parts[1]
+	return p, nil
+}
+
+// validate returns an error if p is malformed
+func (p Path) validate(raw string) error {
+	if p.Container == "" && p.Key != "" {
+		return gopenstack.ErrInvalidPath(raw, "empty container name")
+	}
+	if p.Container == "." || p.Container == ".." {
+		return gopenstack.ErrInvalidPath(raw, "invalid container name")
+	}
+	if len(p.Container) > maxContainerNameLength {
+		return gopenstack.ErrInvalidPath(raw, "container name too long")
+	}
+	if len(p.Key) > maxObjectNameLength {
+		return gopenstack.ErrInvalidPath(raw, "object name too long")
+	}
+	return nil
+}
+
+// Kind returns the kind of p deduced from its syntax
+// (root, container or object, vfolders and symlinks need a request: see osPath.GetType)
+func (p Path) Kind() PathKind {
+	if p.Container == "" {
+		return KindRoot
+	}
+	if p.Key == "" {
+		return KindContainer
+	}
+	return KindObject
+}
+
+// IsRoot returns true if p is the account root
+func (p Path) IsRoot() bool {
+	return p.Container == ""
+}
+
+// String returns p as /container/key
+func (p Path) String() string {
+	if p.Container == "" {
+		return "/"
+	}
+	if p.Key == "" {
+		return "/" + p.Container
+	}
+	return "/" + p.Container + "/" + p.Key
+}
+
+// Prefix returns the listing prefix of p (key + "/") or an empty string for containers
+func (p Path) Prefix() string {
+	if p.Key == "" {
+		return ""
+	}
+	return p.Key + "/"
+}
+
+// Join returns p with elem appended
+// On root, the first element is the container
+func (p Path) Join(elem ...string) Path {
+	for _, e := range elem {
+		e = strings.Trim(e, "/")
+		if e == "" {
+			continue
+		}
+		if p.Container == "" {
+			parts := strings.SplitN(e, "/", 2)
+			p.Container = parts[0]
+			if len(parts) == 2 {
+				p.Key = parts[1]
+			}
+			continue
+		}
+		if p.Key == "" {
+			p.Key = e
+		} else {
+			p.Key += "/" + e
+		}
+	}
+	return p
+}
+
+// Parent returns the parent of p (root is its own parent)
+func (p Path) Parent() Path {
+	if p.Key == "" {
+		p.Container = ""
+		return p
+	}
+	if i := strings.LastIndex(p.Key, "/"); i != -1 {
+		p.Key = p.Key[:i]
+	} else {
+		p.Key = ""
+	}
+	return p
+}
+
+// Base returns the last element of p ("/" for root)
+func (p Path) Base() string {
+	if p.Container == "" {
+		return "/"
+	}
+	if p.Key == "" {
+		return p.Container
+	}
+	return p.Key[strings.LastIndex(p.Key, "/")+1:]
+}
@@ -0,0 +1,167 @@
+package swifttest
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Fault describes an error injected by the server on matching requests
+type Fault struct {
+	Method string // Only match requests with this method (empty: any method)
+	Path   string // Only match paths starting with Path, relative to the account for swift requests (eg /container/object)
+
+	Nth   int // Inject on the Nth matching request (1 based, 0: from the first one)
+	Times int // Number of injections (0: unlimited)
+
+	StatusCode   int           // Respond with this status code (eg 503)
+	Delay        time.Duration // Wait before handling the request (canceled with the request)
+	TruncateBody int           // Abort the connection after TruncateBody bytes of body (0: disabled)
+	WrongEtag    bool          // Respond with a wrong Etag header
+	Unauthorized bool          // Respond 401 as if the token had expired
+
+	matched  int
+	injected int
+}
+
+// A Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+}
+
+// faults holds injected faults and the request log
+type faults struct {
+	mu       sync.Mutex
+	faults   []*Fault
+	requests []Request
+}
+
+// AddFault registers f, faults are evaluated in order and the first matching one is injected
+func (s *Server) AddFault(f Fault) *Fault {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	fault := &f
+	s.faults.faults = append(s.faults.faults, fault)
+	return fault
+}
+
+// Injected returns the number of times f was injected
+func (s *Server) Injected(f *Fault) int {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	return f.injected
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	s.faults.faults = nil
+}
+
+// Requests returns the requests received by the server
+func (s *Server) Requests() []Request {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	return append([]Request(nil), s.faults.requests...)
+}
+
+// ResetRequests clears the request log
+func (s *Server) ResetRequests() {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	s.faults.requests = nil
+}
+
+// ExpireToken revokes the current token: swift requests fail with 401
+// until a new token is issued by keystone
+func (s *Server) ExpireToken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Token = newId()
+}
+
+// match logs r and returns the fault to inject (nil if none)
+func (s *Server) match(r *http.Request) *Fault {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	p := r.URL.Path
+	if prefix := "/v1/" + s.Account; strings.HasPrefix(p, prefix) {
+		p = p[len(prefix):]
+	}
+	s.faults.requests = append(s.faults.requests, Request{r.Method, p, r.URL.RawQuery, r.Header.Clone()})
+	for _, f := range s.faults.faults {
+		if (f.Method != "" && f.Method != r.Method) || !strings.HasPrefix(p, f.Path) {
+			continue
+		}
+		f.matched++
+		if f.matched < f.Nth || (f.Times > 0 && f.injected >= f.Times) {
+			continue
+		}
+		f.injected++
+		return f
+	}
+	return nil
+}
+
+// inject applies f to the request, it returns false if the request must not be handled
+func (f *Fault) inject(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, bool) {
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-r.Context().Done():
+			return w, false
+		}
+	}
+	if f.Unauthorized {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return w, false
+	}
+	if f.StatusCode != 0 {
+		http.Error(w, http.StatusText(f.StatusCode), f.StatusCode)
+		return w, false
+	}
+	if f.WrongEtag || f.TruncateBody > 0 {
+		w = &faultyWriter{ResponseWriter: w, wrongEtag: f.WrongEtag, remaining: f.TruncateBody}
+	}
+	return w, true
+}
+
+// faultyWriter alters the response of a request
+type faultyWriter struct {
+	http.ResponseWriter
+	wrongEtag   bool
+	remaining   int
+	wroteHeader bool
+}
+
+func (w *faultyWriter) WriteHeader(code int) {
+	if !w.wroteHeader && w.wrongEtag && w.Header().Get("Etag") != "" {
+		w.Header().Set("Etag", "00000000000000000000000000000000")
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes at most remaining bytes and then aborts the connection
+func (w *faultyWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.remaining <= 0 {
+		return w.ResponseWriter.Write(b)
+	}
+	if len(b) < w.remaining {
+		w.remaining -= len(b)
+		return w.ResponseWriter.Write(b)
+	}
+	w.ResponseWriter.Write(b[:w.remaining])
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+	panic(http.ErrAbortHandler)
+}
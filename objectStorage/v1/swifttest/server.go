@@ -0,0 +1,202 @@
+// Package swifttest provides a local, in-memory, openstack object storage
+// server (with a stub keystone) to test code using objectStorageV1.
+package swifttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+	"github.com/Toorop/gopenstack/objectStorage/v1"
+)
+
+// Default values of the server
+const (
+	DefaultRegion  = "RegionOne"
+	DefaultAccount = "AUTH_swifttest"
+)
+
+// A Server is an in-memory swift (and keystone) server listening on a local address
+type Server struct {
+	URL     string // Base URL of the server (http://127.0.0.1:port)
+	Region  string // Region of the object-store endpoint in the catalog
+	Account string // Swift account
+	Token   string // Token issued by keystone and required by swift
+
+	// ListingLimit is the max number of entries of a listing page (default 10000)
+	// It must be set before the first request
+	ListingLimit int
+
+	srv        *httptest.Server
+	mu         sync.Mutex
+	containers map[string]*container
+	faults     faults
+}
+
+// NewServer starts and returns a new Server
+// The caller should call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		Region:       DefaultRegion,
+		Account:      DefaultAccount,
+		Token:        newId(),
+		ListingLimit: listingLimit,
+		containers:   make(map[string]*container),
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// token returns the current valid token
+func (s *Server) token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Token
+}
+
+// AuthURL returns the keystone v3 URL of the server
+func (s *Server) AuthURL() string {
+	return s.URL + "/v3"
+}
+
+// Endpoint returns the object-store endpoint of the server
+func (s *Server) Endpoint() string {
+	return s.URL + "/v1/" + s.Account
+}
+
+// Keyring authenticates against the stub keystone and returns the keyring
+func (s *Server) Keyring() (*gopenstack.Keyring, error) {
+	return gopenstack.NewKeyring(&gopenstack.AuthOptions{
+		AuthUrl:           s.AuthURL(),
+		Username:          "swifttest",
+		Password:          "swifttest",
+		UserDomainName:    "Default",
+		ProjectName:       "swifttest",
+		ProjectDomainName: "Default",
+	})
+}
+
+// Swift returns a Swift client connected to the server
+func (s *Server) Swift() (*objectStorageV1.Swift, error) {
+	keyring, err := s.Keyring()
+	if err != nil {
+		return nil, err
+	}
+	client, err := objectStorageV1.NewClient(keyring, s.Region)
+	if err != nil {
+		return nil, err
+	}
+	return objectStorageV1.NewSwift(client), nil
+}
+
+// ServeHTTP dispatches requests to keystone, /info or swift handlers
+// after injecting faults (see AddFault)
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f := s.match(r); f != nil {
+		var ok bool
+		if w, ok = f.inject(w, r); !ok {
+			return
+		}
+	}
+	switch {
+	case r.URL.Path == "/v3/auth/tokens":
+		s.serveTokens(w, r)
+	case r.URL.Path == "/info":
+		s.serveInfo(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v1/"):
+		if r.Header.Get("X-Auth-Token") != s.token() {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		s.serveSwift(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveTokens issues a token (any credentials are accepted)
+// The catalog has an object-store endpoint pointing to the server
+func (s *Server) serveTokens(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	now := time.Now().UTC()
+	token := map[string]interface{}{
+		"methods":    []string{"password"},
+		"issued_at":  now.Format(time.RFC3339),
+		"expires_at": now.Add(24 * time.Hour).Format(time.RFC3339),
+		"user": map[string]interface{}{
+			"id":     "swifttest",
+			"name":   "swifttest",
+			"domain": map[string]string{"name": "Default"},
+		},
+		"project": map[string]interface{}{
+			"id":     strings.TrimPrefix(s.Account, "AUTH_"),
+			"name":   "swifttest",
+			"domain": map[string]string{"name": "Default"},
+		},
+		"roles": []map[string]string{{"id": "member", "name": "member"}},
+		"catalog": []map[string]interface{}{{
+			"id":   "swift",
+			"type": "object-store",
+			"endpoints": []map[string]string{{
+				"id":        "swift-public",
+				"interface": "public",
+				"region":    s.Region,
+				"url":       s.Endpoint(),
+			}},
+		}},
+	}
+	w.Header().Set("X-Subject-Token", s.token())
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"token": token})
+}
+
+// serveInfo returns the cluster capabilities
+func (s *Server) serveInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"swift": map[string]interface{}{
+			"version":                   "swifttest",
+			"max_file_size":             maxFileSize,
+			"max_object_name_length":    1024,
+			"max_container_name_length": 256,
+			"container_listing_limit":   s.ListingLimit,
+			"account_listing_limit":     s.ListingLimit,
+			"max_meta_name_length":      128,
+			"max_meta_value_length":     256,
+			"policies":                  []map[string]interface{}{{"name": "Policy-0", "default": true}},
+		},
+		"bulk_delete": map[string]int{"max_deletes_per_request": 10000, "max_failed_deletes": 1000},
+		"bulk_upload": map[string]int{"max_containers_per_extraction": 10000, "max_failed_extractions": 1000},
+		"symlink":     map[string]interface{}{"symlink_loop_limit": symlinkLoopLimit, "static_links": true},
+		"versioned_writes": map[string]interface{}{
+			"allowed_flags": []string{"x-versions-location", "x-history-location"},
+		},
+	})
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// newId returns a random hex id
+func newId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,687 @@
+package swifttest
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server limits
+const (
+	maxFileSize      = 5 * 1024 * 1024 * 1024
+	listingLimit     = 10000
+	symlinkLoopLimit = 2
+)
+
+// container is a stored container
+type container struct {
+	name     string
+	metadata http.Header // X-Container-Meta-* & co
+	objects  map[string]*object
+	modified time.Time
+}
+
+// object is a stored object
+type object struct {
+	name          string
+	data          []byte
+	etag          string
+	contentType   string
+	metadata      http.Header // X-Object-Meta-*, Content-Encoding...
+	modified      time.Time
+	deleteAt      time.Time
+	symlinkTarget string // container/object
+}
+
+// expired returns true if o must be considered as deleted
+func (o *object) expired() bool {
+	return !o.deleteAt.IsZero() && !time.Now().Before(o.deleteAt)
+}
+
+// listingEntry is an entry of a JSON listing
+type listingEntry struct {
+	Name         string `json:"name,omitempty"`
+	Subdir       string `json:"subdir,omitempty"`
+	Hash         string `json:"hash,omitempty"`
+	Bytes        int64  `json:"bytes"`
+	Count        int64  `json:"count,omitempty"`
+	ContentType  string `json:"content_type,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	SymlinkPath  string `json:"symlink_path,omitempty"`
+}
+
+// serveSwift handles /v1/account[/container[/object]] requests
+func (s *Server) serveSwift(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/"), "/", 3)
+	if parts[0] != s.Account {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	containerName, objectName := "", ""
+	if len(parts) > 1 {
+		containerName = parts[1]
+	}
+	if len(parts) > 2 {
+		objectName = parts[2]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	if _, ok := query["bulk-delete"]; ok && containerName == "" && (r.Method == "POST" || r.Method == "DELETE") {
+		s.bulkDelete(w, r)
+		return
+	}
+	if format, ok := query["extract-archive"]; ok && r.Method == "PUT" {
+		s.extractArchive(w, r, containerName, objectName, format[0])
+		return
+	}
+
+	switch {
+	case containerName == "":
+		s.serveAccount(w, r)
+	case objectName == "":
+		s.serveContainer(w, r, containerName)
+	default:
+		s.serveObject(w, r, containerName, objectName)
+	}
+}
+
+// serveAccount handles account requests
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request) {
+	var objects, bytesUsed int64
+	for _, c := range s.containers {
+		for _, o := range c.objects {
+			if !o.expired() {
+				objects++
+				bytesUsed += int64(len(o.data))
+			}
+		}
+	}
+	h := w.Header()
+	h.Set("X-Account-Container-Count", strconv.Itoa(len(s.containers)))
+	h.Set("X-Account-Object-Count", strconv.FormatInt(objects, 10))
+	h.Set("X-Account-Bytes-Used", strconv.FormatInt(bytesUsed, 10))
+
+	switch r.Method {
+	case "HEAD":
+		w.WriteHeader(http.StatusNoContent)
+	case "GET":
+		names := make([]string, 0, len(s.containers))
+		for name := range s.containers {
+			names = append(names, name)
+		}
+		entries := listing(names, r.URL.Query(), s.ListingLimit, func(name string) listingEntry {
+			c := s.containers[name]
+			e := listingEntry{Name: name, LastModified: formatListingTime(c.modified)}
+			for _, o := range c.objects {
+				if !o.expired() {
+					e.Count++
+					e.Bytes += int64(len(o.data))
+				}
+			}
+			return e
+		})
+		writeListing(w, r, entries)
+	case "POST":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveContainer handles container requests
+func (s *Server) serveContainer(w http.ResponseWriter, r *http.Request, name string) {
+	c := s.containers[name]
+	if c == nil && r.Method != "PUT" {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "PUT":
+		code := http.StatusAccepted
+		if c == nil {
+			c = s.addContainer(name)
+			code = http.StatusCreated
+		}
+		if !updateVersionsLocation(w, c, r.Header) {
+			return
+		}
+		updateMetadata(c.metadata, r.Header, "X-Container-Meta-", false)
+		w.WriteHeader(code)
+	case "POST":
+		if !updateVersionsLocation(w, c, r.Header) {
+			return
+		}
+		updateMetadata(c.metadata, r.Header, "X-Container-Meta-", false)
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		for _, o := range c.objects {
+			if !o.expired() {
+				http.Error(w, "There was a conflict when trying to complete your request.", http.StatusConflict)
+				return
+			}
+		}
+		delete(s.containers, name)
+		w.WriteHeader(http.StatusNoContent)
+	case "HEAD", "GET":
+		s.containerHeaders(w.Header(), c)
+		if r.Method == "HEAD" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		names := make([]string, 0, len(c.objects))
+		for name, o := range c.objects {
+			if !o.expired() {
+				names = append(names, name)
+			}
+		}
+		entries := listing(names, r.URL.Query(), s.ListingLimit, func(name string) listingEntry {
+			o := c.objects[name]
+			e := listingEntry{
+				Name:         name,
+				Hash:         o.etag,
+				Bytes:        int64(len(o.data)),
+				ContentType:  o.contentType,
+				LastModified: formatListingTime(o.modified),
+			}
+			if o.symlinkTarget != "" {
+				e.SymlinkPath = "/v1/" + s.Account + "/" + o.symlinkTarget
+			}
+			return e
+		})
+		writeListing(w, r, entries)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// addContainer creates container name
+func (s *Server) addContainer(name string) *container {
+	c := &container{
+		name:     name,
+		metadata: make(http.Header),
+		objects:  make(map[string]*object),
+		modified: time.Now().UTC(),
+	}
+	s.containers[name] = c
+	return c
+}
+
+// containerHeaders sets container headers
+func (s *Server) containerHeaders(h http.Header, c *container) {
+	var count, bytesUsed int64
+	for _, o := range c.objects {
+		if !o.expired() {
+			count++
+			bytesUsed += int64(len(o.data))
+		}
+	}
+	for k, v := range c.metadata {
+		h[k] = v
+	}
+	h.Set("X-Container-Object-Count", strconv.FormatInt(count, 10))
+	h.Set("X-Container-Bytes-Used", strconv.FormatInt(bytesUsed, 10))
+	h.Set("X-Storage-Policy", "Policy-0")
+	h.Set("X-Timestamp", formatTimestamp(c.modified))
+	h.Set("Last-Modified", c.modified.Format(http.TimeFormat))
+}
+
+// serveObject handles object requests
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, containerName, name string) {
+	c := s.containers[containerName]
+	if c == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method == "PUT" {
+		s.putObject(w, r, c, name)
+		return
+	}
+	o := c.objects[name]
+	if o != nil && o.expired() {
+		delete(c.objects, name)
+		o = nil
+	}
+	if o == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "HEAD", "GET":
+		if _, ok := r.URL.Query()["symlink"]; !ok && o.symlinkTarget != "" {
+			var err error
+			if o, err = s.followSymlink(o); err != nil {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			if o == nil {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Location", "/v1/"+s.Account+"/"+path.Join(containerName, name))
+		}
+		objectHeaders(w.Header(), o)
+		if r.Header.Get("Range") == "" {
+			// ServeContent omits it when Content-Encoding is set
+			w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		}
+		http.ServeContent(w, r, "", o.modified, bytes.NewReader(o.data))
+	case "POST":
+		// POST replaces the user metadata
+		updateMetadata(o.metadata, r.Header, "X-Object-Meta-", true)
+		for _, k := range []string{"Content-Encoding", "Content-Disposition"} {
+			o.metadata.Del(k)
+			if v := r.Header.Get(k); v != "" {
+				o.metadata.Set(k, v)
+			}
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "" {
+			o.contentType = ct
+		}
+		if err := setDeleteAt(o, r.Header); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Remove-Delete-At") != "" {
+			o.deleteAt = time.Time{}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case "DELETE":
+		delete(c.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// putObject stores the request body as object name of c
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, c *container, name string) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxFileSize {
+		http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	o := &object{
+		name:     name,
+		data:     data,
+		etag:     fmt.Sprintf("%x", md5.Sum(data)),
+		metadata: make(http.Header),
+		modified: time.Now().UTC(),
+	}
+	if etag := strings.Trim(r.Header.Get("Etag"), `"`); etag != "" && etag != o.etag {
+		http.Error(w, "Unprocessable Entity", http.StatusUnprocessableEntity)
+		return
+	}
+	if target := r.Header.Get("X-Symlink-Target"); target != "" {
+		if len(data) != 0 {
+			http.Error(w, "Symlink requests require a zero byte body", http.StatusBadRequest)
+			return
+		}
+		if o.symlinkTarget, err = url.PathUnescape(target); err != nil || !strings.Contains(o.symlinkTarget, "/") {
+			http.Error(w, "X-Symlink-Target header must be of the form <container name>/<object name>", http.StatusPreconditionFailed)
+			return
+		}
+		if etag := r.Header.Get("X-Symlink-Target-Etag"); etag != "" {
+			t, _ := s.lookup(o.symlinkTarget)
+			if t == nil || t.etag != strings.Trim(etag, `"`) {
+				http.Error(w, "Object Etag does not match X-Symlink-Target-Etag", http.StatusConflict)
+				return
+			}
+		}
+	}
+	o.contentType = r.Header.Get("Content-Type")
+	if o.contentType == "" {
+		o.contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if o.contentType == "" {
+		o.contentType = "application/octet-stream"
+	}
+	updateMetadata(o.metadata, r.Header, "X-Object-Meta-", true)
+	for _, k := range []string{"Content-Encoding", "Content-Disposition"} {
+		if v := r.Header.Get(k); v != "" {
+			o.metadata.Set(k, v)
+		}
+	}
+	if err = setDeleteAt(o, r.Header); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.objects[name] = o
+	w.Header().Set("Etag", o.etag)
+	w.Header().Set("Last-Modified", o.modified.Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// lookup returns the object at container/object path
+func (s *Server) lookup(p string) (*object, *container) {
+	parts := strings.SplitN(p, "/", 2)
+	c := s.containers[parts[0]]
+	if c == nil || len(parts) != 2 {
+		return nil, c
+	}
+	o := c.objects[parts[1]]
+	if o == nil || o.expired() {
+		return nil, c
+	}
+	return o, c
+}
+
+// followSymlink returns the object targeted by symlink o (nil if dangling)
+func (s *Server) followSymlink(o *object) (*object, error) {
+	for i := 0; o != nil && o.symlinkTarget != ""; i++ {
+		if i > symlinkLoopLimit {
+			return nil, fmt.Errorf("Too many levels of symbolic links, maximum allowed is %d", symlinkLoopLimit)
+		}
+		o, _ = s.lookup(o.symlinkTarget)
+	}
+	return o, nil
+}
+
+// objectHeaders sets object headers
+func objectHeaders(h http.Header, o *object) {
+	for k, v := range o.metadata {
+		h[k] = v
+	}
+	h.Set("Etag", o.etag)
+	h.Set("Content-Type", o.contentType)
+	h.Set("X-Timestamp", formatTimestamp(o.modified))
+	h.Set("Accept-Ranges", "bytes")
+	if !o.deleteAt.IsZero() {
+		h.Set("X-Delete-At", strconv.FormatInt(o.deleteAt.Unix(), 10))
+	}
+	if o.symlinkTarget != "" {
+		h.Set("X-Symlink-Target", o.symlinkTarget)
+	}
+}
+
+// setDeleteAt sets expiration of o from X-Delete-At / X-Delete-After headers
+func setDeleteAt(o *object, h http.Header) error {
+	if v := h.Get("X-Delete-At"); v != "" {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Non-integer X-Delete-At")
+		}
+		o.deleteAt = time.Unix(ts, 0)
+	}
+	if v := h.Get("X-Delete-After"); v != "" {
+		ttl, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Non-integer X-Delete-After")
+		}
+		o.deleteAt = time.Now().Add(time.Duration(ttl) * time.Second)
+	}
+	return nil
+}
+
+// updateVersionsLocation handles legacy versioning headers of a container request
+// As with versioned_writes, a container has a single location in either mode:
+// both can't be set by a request and setting one replaces the other.
+// Versions are not archived. It returns false if the request was rejected.
+func updateVersionsLocation(w http.ResponseWriter, c *container, h http.Header) bool {
+	versions, history := h.Get("X-Versions-Location"), h.Get("X-History-Location")
+	if versions != "" && history != "" {
+		http.Error(w, "Only one of X-Versions-Location or X-History-Location may be specified", http.StatusBadRequest)
+		return false
+	}
+	for _, k := range []string{"Versions", "History"} {
+		if h.Get("X-Remove-"+k+"-Location") != "" {
+			c.metadata.Del("X-" + k + "-Location")
+		}
+	}
+	if versions != "" {
+		c.metadata.Del("X-History-Location")
+	}
+	if history != "" {
+		c.metadata.Del("X-Versions-Location")
+	}
+	return true
+}
+
+// updateMetadata updates metadata with headers starting with prefix
+// If replace is true, existing metadata starting with prefix are removed
+func updateMetadata(metadata, headers http.Header, prefix string, replace bool) {
+	if replace {
+		for k := range metadata {
+			if strings.HasPrefix(k, prefix) {
+				delete(metadata, k)
+			}
+		}
+	}
+	removePrefix := "X-Remove-" + strings.TrimPrefix(prefix, "X-")
+	for k, v := range headers {
+		switch {
+		case strings.HasPrefix(k, prefix):
+			if v[0] == "" {
+				delete(metadata, k)
+			} else {
+				metadata[k] = v
+			}
+		case strings.HasPrefix(k, removePrefix):
+			delete(metadata, prefix+k[len(removePrefix):])
+		case strings.HasPrefix(k, "X-Versions-") || strings.HasPrefix(k, "X-History-") || k == "X-Container-Read" || k == "X-Container-Write":
+			metadata[k] = v
+		}
+	}
+}
+
+// listing returns entries of names filtered by prefix, delimiter, marker,
+// end_marker and limit query parameters (at most max entries)
+func listing(names []string, query url.Values, max int, entry func(name string) listingEntry) (entries []listingEntry) {
+	sort.Strings(names)
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+	marker, endMarker := query.Get("marker"), query.Get("end_marker")
+	limit := max
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l >= 0 && l < limit {
+		limit = l
+	}
+	lastSubdir := ""
+	for _, name := range names {
+		if len(entries) >= limit {
+			break
+		}
+		if name <= marker || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if endMarker != "" && name >= endMarker {
+			break
+		}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i != -1 {
+				subdir := name[:len(prefix)+i+len(delimiter)]
+				if subdir != lastSubdir && subdir != marker {
+					entries = append(entries, listingEntry{Subdir: subdir})
+				}
+				lastSubdir = subdir
+				continue
+			}
+		}
+		entries = append(entries, entry(name))
+	}
+	return
+}
+
+// writeListing writes entries as JSON or plain text (format query parameter)
+func writeListing(w http.ResponseWriter, r *http.Request, entries []listingEntry) {
+	if r.URL.Query().Get("format") == "json" {
+		if entries == nil {
+			entries = []listingEntry{}
+		}
+		writeJSON(w, http.StatusOK, entries)
+		return
+	}
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, e := range entries {
+		if e.Subdir != "" {
+			fmt.Fprintln(w, e.Subdir)
+		} else {
+			fmt.Fprintln(w, e.Name)
+		}
+	}
+}
+
+// bulkDelete handles ?bulk-delete requests
+func (s *Server) bulkDelete(w http.ResponseWriter, r *http.Request) {
+	result := map[string]interface{}{
+		"Response Status":  "200 OK",
+		"Response Body":    "",
+		"Number Deleted":   0,
+		"Number Not Found": 0,
+	}
+	deleted, notFound := 0, 0
+	errs := [][]string{}
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		p, err := url.PathUnescape(line)
+		if err != nil {
+			errs = append(errs, []string{line, "400 Bad Request"})
+			continue
+		}
+		p = strings.TrimPrefix(p, "/")
+		o, c := s.lookup(p)
+		switch {
+		case c == nil:
+			notFound++
+		case !strings.Contains(p, "/"):
+			// container
+			if len(c.objects) != 0 {
+				errs = append(errs, []string{"/" + p, "409 Conflict"})
+				continue
+			}
+			delete(s.containers, p)
+			deleted++
+		case o == nil:
+			notFound++
+		default:
+			delete(c.objects, p[len(c.name)+1:])
+			deleted++
+		}
+	}
+	result["Number Deleted"] = deleted
+	result["Number Not Found"] = notFound
+	result["Errors"] = errs
+	if len(errs) != 0 {
+		result["Response Status"] = "400 Bad Request"
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// extractArchive handles ?extract-archive=tar|tar.gz|tar.bz2 requests
+func (s *Server) extractArchive(w http.ResponseWriter, r *http.Request, containerName, prefix, format string) {
+	var reader io.Reader = r.Body
+	var err error
+	switch format {
+	case "tar":
+	case "tar.gz", "tgz":
+		if reader, err = gzip.NewReader(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "tar.bz2":
+		reader = bzip2.NewReader(r.Body)
+	default:
+		http.Error(w, "Unsupported archive format", http.StatusBadRequest)
+		return
+	}
+	created := 0
+	errs := [][]string{}
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, "Invalid Tar File: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		p := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if prefix != "" {
+			p = prefix + "/" + p
+		}
+		if containerName != "" {
+			p = containerName + "/" + p
+		}
+		parts := strings.SplitN(p, "/", 2)
+		if len(parts) != 2 {
+			errs = append(errs, []string{"/" + p, "400 Bad Request"})
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c := s.containers[parts[0]]
+		if c == nil {
+			if containerName != "" {
+				errs = append(errs, []string{"/" + p, "404 Not Found"})
+				continue
+			}
+			c = s.addContainer(parts[0])
+		}
+		contentType := mime.TypeByExtension(path.Ext(parts[1]))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		c.objects[parts[1]] = &object{
+			name:        parts[1],
+			data:        data,
+			etag:        fmt.Sprintf("%x", md5.Sum(data)),
+			contentType: contentType,
+			metadata:    make(http.Header),
+			modified:    time.Now().UTC(),
+		}
+		created++
+	}
+	status := "201 Created"
+	if len(errs) != 0 {
+		status = "400 Bad Request"
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"Number Files Created": created,
+		"Response Status":      status,
+		"Response Body":        "",
+		"Errors":               errs,
+	})
+}
+
+// formatListingTime formats t as in swift listings
+func formatListingTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000")
+}
+
+// formatTimestamp formats t as a swift X-Timestamp
+func formatTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%05d", t.Unix(), t.Nanosecond()/10000)
+}
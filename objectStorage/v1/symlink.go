@@ -0,0 +1,130 @@
+package objectStorageV1
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// maxSymlinkLoop is the max number of chained symlinks followed by ResolveSymlink
+const maxSymlinkLoop = 10
+
+// AddSymlink creates a dynamic symlink at path pointing to target (container/object)
+func (s *Swift) AddSymlink(path, target string) error {
+	if c, err := s.getCapabilities(); err == nil && c.Symlink == nil {
+		return gopenstack.ErrSymlinkNotAvailable
+	}
+	return s.putSymlink(path, target, "")
+}
+
+// AddStaticSymlink creates a static symlink at path pointing to target (container/object)
+// A static symlink is bound to the current etag of target
+func (s *Swift) AddStaticSymlink(path, target string) error {
+	if c, err := s.getCapabilities(); err == nil {
+		if c.Symlink == nil {
+			return gopenstack.ErrSymlinkNotAvailable
+		}
+		if !c.Symlink.StaticLinks {
+			return gopenstack.ErrStaticSymlinkNotAvailable
+		}
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: escapePath(target),
+	})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return err
+	}
+	if resp.StatusCode == 404 {
+		return gopenstack.ErrPathNotFound(target)
+	}
+	return s.putSymlink(path, target, strings.Trim(resp.Headers.Get("Etag"), `"`))
+}
+
+// putSymlink creates symlink path -> target
+func (s *Swift) putSymlink(path, target, etag string) error {
+	headers := make(map[string]string)
+	headers["Content-Length"] = "0"
+	headers["X-Symlink-Target"] = escapePath(strings.TrimPrefix(target, "/"))
+	if etag != "" {
+		headers["X-Symlink-Target-Etag"] = etag
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: escapePath(path),
+		Headers:   headers,
+	})
+	return resp.HandleErr(err, []int{201})
+}
+
+// ResolveSymlink returns the path targeted by p, following chained symlinks
+// If p is not a symlink, p is returned
+func (p *osPath) ResolveSymlink() (*osPath, error) {
+	t := p
+	for i := 0; i <= maxSymlinkLoop; i++ {
+		pathType, err := t.GetType()
+		if err != nil {
+			return nil, err
+		}
+		if pathType != KindSymlink {
+			return t, nil
+		}
+		t = NewOsPath(p.client, t.SymlinkTarget)
+	}
+	return nil, gopenstack.ErrSymlinkLoop
+}
+
+// downloadSymlink creates dest as a local symlink if the target of the swift
+// symlink src is part of the download (localDestOf), otherwise downloads src content
+func (s *Swift) downloadSymlink(src, dest, symlinkPath string, localDestOf func(container, name string) (string, bool)) error {
+	// symlinkPath is /v1/account/container/object
+	unescaped, err := url.PathUnescape(symlinkPath)
+	if err != nil {
+		return err
+	}
+	sp, err := ParseFullPath(unescaped)
+	if err != nil || sp.Kind() != KindObject {
+		return s.DownloadObject(src, dest)
+	}
+	container, name := sp.Container, sp.Key
+	target, ok := localDestOf(container, name)
+	if !ok {
+		return s.DownloadObject(src, dest)
+	}
+	rel, err := filepath.Rel(filepath.Dir(dest), target)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	os.Remove(dest)
+	return os.Symlink(rel, dest)
+}
+
+// remoteSymlinkTarget returns the remote target of local file path if it is
+// a symlink pointing inside srcRoot and options ask to preserve symlinks
+func remoteSymlinkTarget(path, srcRoot, destRoot string, options *PutOptions) (string, bool) {
+	if options == nil || !options.PreserveSymlinks {
+		return "", false
+	}
+	fi, err := os.Lstat(path)
+	if err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		return "", false
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	rel, err := filepath.Rel(srcRoot, filepath.Clean(target))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return destRoot + "/" + filepath.ToSlash(rel), true
+}
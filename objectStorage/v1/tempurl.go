@@ -0,0 +1,81 @@
+package objectStorageV1
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// TempURL returns a temporary URL allowing method (GET, PUT...) on object path during ttl
+// If key is empty, the account Temp-URL-Key is used
+func (s *Swift) TempURL(method, path string, ttl time.Duration, key string) (string, error) {
+	p, err := ParsePath(path)
+	if err != nil {
+		return "", err
+	}
+	if p.Kind() != KindObject {
+		return "", gopenstack.ErrNoObjectSpecified
+	}
+
+	// digest (sha256 if capabilities are unknown, supported by current clusters)
+	digest := sha256.New
+	if c, err := s.getCapabilities(); err == nil {
+		if c.TempURL == nil {
+			return "", gopenstack.ErrTempURLNotAvailable
+		}
+		digest = tempURLDigest(c.TempURL.AllowedDigests)
+	}
+
+	if key == "" {
+		resp, err := s.client.Call(&gopenstack.CallOptions{
+			Method:    "HEAD",
+			Ressource: "",
+		})
+		if err = resp.HandleErr(err, []int{200, 204}); err != nil {
+			return "", err
+		}
+		if key = resp.Headers.Get("X-Account-Meta-Temp-Url-Key"); key == "" {
+			return "", gopenstack.ErrNoTempURLKey
+		}
+	}
+
+	u, err := url.Parse(s.client.GetEndpoint())
+	if err != nil {
+		return "", err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + p.String()
+	expires := time.Now().Add(ttl).Unix()
+	sig := tempURLSignature(digest, key, fmt.Sprintf("%s\n%d\n%s", strings.ToUpper(method), expires, u.Path))
+	u.RawQuery = fmt.Sprintf("temp_url_sig=%s&temp_url_expires=%d", sig, expires)
+	return u.String(), nil
+}
+
+// tempURLSignature returns the hex HMAC of body
+func tempURLSignature(digest func() hash.Hash, key, body string) string {
+	mac := hmac.New(digest, []byte(key))
+	mac.Write([]byte(body))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+// tempURLDigest returns the digest to sign with: sha256 if allowed, else sha512, else sha1
+func tempURLDigest(allowed []string) func() hash.Hash {
+	digest := sha1.New
+	for _, d := range allowed {
+		if d == "sha256" {
+			digest = sha256.New
+			break
+		}
+		if d == "sha512" {
+			digest = sha512.New
+		}
+	}
+	return digest
+}
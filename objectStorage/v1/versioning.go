@@ -0,0 +1,214 @@
+package objectStorageV1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/Toorop/gopenstack"
+)
+
+// SetVersionsEnabled enables (or suspends) object versioning on container
+// using X-Versions-Enabled (object_versioning middleware)
+func (s *Swift) SetVersionsEnabled(container string, enabled bool) error {
+	if c, err := s.getCapabilities(); err != nil {
+		return err
+	} else if c.ObjectVersioning == nil {
+		return gopenstack.ErrVersioningNotAvailable
+	}
+	headers := make(map[string]string)
+	headers["X-Versions-Enabled"] = "false"
+	if enabled {
+		headers["X-Versions-Enabled"] = "true"
+	}
+	return s.postContainer(container, headers)
+}
+
+// SetVersionsLocation enables legacy versioning on container (versioned_writes middleware)
+// Previous versions are stored in the location container.
+// If history is true X-History-Location is used (deletes are versioned too),
+// otherwise X-Versions-Location. The other one is removed.
+// An empty location disables versioning.
+func (s *Swift) SetVersionsLocation(container, location string, history bool) error {
+	if c, err := s.getCapabilities(); err != nil {
+		return err
+	} else if c.VersionedWrites == nil {
+		return gopenstack.ErrVersioningNotAvailable
+	}
+	headers := make(map[string]string)
+	if location == "" {
+		headers["X-Remove-Versions-Location"] = "x"
+		headers["X-Remove-History-Location"] = "x"
+		return s.postContainer(container, headers)
+	}
+	if err := s.AddContainer(location); err != nil {
+		return err
+	}
+	if history {
+		headers["X-History-Location"] = location
+		headers["X-Remove-Versions-Location"] = "x"
+	} else {
+		headers["X-Versions-Location"] = location
+		headers["X-Remove-History-Location"] = "x"
+	}
+	return s.postContainer(container, headers)
+}
+
+// postContainer updates container headers
+func (s *Swift) postContainer(container string, headers map[string]string) error {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "POST",
+		Ressource: url.QueryEscape(container),
+		Headers:   headers,
+	})
+	return resp.HandleErr(err, []int{202, 204})
+}
+
+// checkLegacyVersioning returns ErrLegacyVersioning if container headers
+// have a versions or history location (versioned_writes middleware)
+func checkLegacyVersioning(headers http.Header) error {
+	if headers.Get("X-Versions-Location") != "" || headers.Get("X-History-Location") != "" {
+		return gopenstack.ErrLegacyVersioning
+	}
+	return nil
+}
+
+// checkVersionedContainer returns ErrLegacyVersioning if container uses legacy versioning
+// The version-id parameter is ignored by such containers.
+func (s *Swift) checkVersionedContainer(container string) error {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "HEAD",
+		Ressource: url.QueryEscape(container),
+	})
+	if err = resp.HandleErr(err, []int{200, 204, 404}); err != nil {
+		return err
+	}
+	return checkLegacyVersioning(resp.Headers)
+}
+
+// ListObjectVersions returns versions of object at path (newest first)
+// It returns ErrLegacyVersioning if the container uses legacy versioning.
+func (s *Swift) ListObjectVersions(path string) (versions []Object, err error) {
+	p := NewOsPath(s.client, path)
+	container := p.GetContainer()
+	name := p.GetPrefix()
+	if name == "" {
+		return versions, gopenstack.ErrNoObjectSpecified
+	}
+	name = name[:len(name)-1]
+
+	marker, versionMarker := "", ""
+	for {
+		resp, err := s.client.Call(&gopenstack.CallOptions{
+			Method:    "GET",
+			Ressource: url.QueryEscape(container) + "?format=json&versions&prefix=" + url.QueryEscape(name) + "&marker=" + url.QueryEscape(marker) + "&version_marker=" + url.QueryEscape(versionMarker),
+		})
+		if err = resp.HandleErr(err, []int{200, 204}); err != nil {
+			return versions, err
+		}
+		if err = checkLegacyVersioning(resp.Headers); err != nil {
+			return versions, err
+		}
+		if resp.StatusCode == 204 {
+			return versions, nil
+		}
+		var page []Object
+		if err = json.Unmarshal(resp.Body, &page); err != nil {
+			return versions, err
+		}
+		if len(page) == 0 {
+			return versions, nil
+		}
+		for _, o := range page {
+			if o.Name == name {
+				versions = append(versions, o)
+			}
+		}
+		last := page[len(page)-1]
+		if last.Name != name {
+			return versions, nil
+		}
+		marker, versionMarker = last.Name, last.VersionId
+	}
+}
+
+// DownloadObjectVersion download and save to dest the version versionId of src object
+func (s *Swift) DownloadObjectVersion(src, versionId, dest string) error {
+	if err := s.checkVersionedContainer(NewOsPath(s.client, src).GetContainer()); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:             "GET",
+		Ressource:          escapePath(src) + "?version-id=" + url.QueryEscape(versionId),
+		ReturnBodyAsReader: true,
+	})
+	if err = resp.HandleErr(err, []int{200}); err != nil {
+		return err
+	}
+	i := resp.BodyReader
+	defer i.Close()
+	o, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer o.Close()
+	_, err = io.Copy(o, i)
+	return err
+}
+
+// RestoreObjectVersion makes version versionId the current version of object at path
+func (s *Swift) RestoreObjectVersion(path, versionId string) error {
+	if err := s.checkVersionedContainer(NewOsPath(s.client, path).GetContainer()); err != nil {
+		return err
+	}
+	headers := make(map[string]string)
+	headers["Content-Length"] = "0"
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "PUT",
+		Ressource: escapePath(path) + "?version-id=" + url.QueryEscape(versionId),
+		Headers:   headers,
+	})
+	return resp.HandleErr(err, []int{201, 202})
+}
+
+// DeleteObjectVersion deletes the version versionId of object at path
+func (s *Swift) DeleteObjectVersion(path, versionId string) error {
+	if err := s.checkVersionedContainer(NewOsPath(s.client, path).GetContainer()); err != nil {
+		return err
+	}
+	return s.deleteObjectVersion(path, versionId)
+}
+
+// deleteObjectVersion deletes the version versionId of object at path
+// without checking the versioning mode of the container
+func (s *Swift) deleteObjectVersion(path, versionId string) error {
+	resp, err := s.client.Call(&gopenstack.CallOptions{
+		Method:    "DELETE",
+		Ressource: escapePath(path) + "?version-id=" + url.QueryEscape(versionId),
+	})
+	return resp.HandleErr(err, []int{204, 404})
+}
+
+// DeleteOldVersions deletes versions of object at path, keeping the keep newest ones
+// The current version is never deleted
+func (s *Swift) DeleteOldVersions(path string, keep int) error {
+	versions, err := s.ListObjectVersions(path)
+	if err != nil {
+		return err
+	}
+	for k, v := range versions {
+		if k < keep || v.IsLatest {
+			continue
+		}
+		if err = s.deleteObjectVersion(path, v.VersionId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package objectStorageV1_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/Toorop/gopenstack"
+)
+
+func TestSetVersionsLocation(t *testing.T) {
+	srv, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"o": "content"})
+
+	for _, history := range []bool{true, false, true} {
+		srv.ResetRequests()
+		if err := s.SetVersionsLocation("c", "archive", history); err != nil {
+			t.Fatalf("SetVersionsLocation history=%v: %v", history, err)
+		}
+		set, removed := "X-Versions-Location", "X-Remove-History-Location"
+		if history {
+			set, removed = "X-History-Location", "X-Remove-Versions-Location"
+		}
+		var posted bool
+		for _, r := range srv.Requests() {
+			if r.Method == "POST" && r.Path == "/c" {
+				posted = true
+				if r.Header.Get(set) != "archive" || r.Header.Get(removed) == "" {
+					t.Errorf("history=%v: POST headers %v, want %s and %s", history, r.Header, set, removed)
+				}
+			}
+		}
+		if !posted {
+			t.Fatalf("history=%v: container not updated", history)
+		}
+		c, err := s.HeadContainer("archive")
+		if err != nil || c.Name != "archive" {
+			t.Errorf("location container: %v", err)
+		}
+	}
+
+	if err := s.SetVersionsLocation("c", "", false); err != nil {
+		t.Fatalf("SetVersionsLocation disable: %v", err)
+	}
+	if _, err := s.ListObjectVersions("/c/o"); err == gopenstack.ErrLegacyVersioning {
+		t.Errorf("ListObjectVersions after disabling legacy versioning: %v", err)
+	}
+}
+
+func TestLegacyVersioning(t *testing.T) {
+	_, s, _ := newTestSwift(t)
+	putObjects(t, s, map[string]string{"o": "content"})
+	if err := s.SetVersionsLocation("c", "archive", true); err != nil {
+		t.Fatal(err)
+	}
+
+	// version-id would be ignored: nothing must be sent to the object
+	if _, err := s.ListObjectVersions("/c/o"); err != gopenstack.ErrLegacyVersioning {
+		t.Errorf("ListObjectVersions: %v", err)
+	}
+	dest := filepath.Join(t.TempDir(), "o")
+	if err := s.DownloadObjectVersion("/c/o", "1", dest); err != gopenstack.ErrLegacyVersioning {
+		t.Errorf("DownloadObjectVersion: %v", err)
+	}
+	if err := s.RestoreObjectVersion("/c/o", "1"); err != gopenstack.ErrLegacyVersioning {
+		t.Errorf("RestoreObjectVersion: %v", err)
+	}
+	if err := s.DeleteObjectVersion("/c/o", "1"); err != gopenstack.ErrLegacyVersioning {
+		t.Errorf("DeleteObjectVersion: %v", err)
+	}
+	if err := s.DeleteOldVersions("/c/o", 0); err != gopenstack.ErrLegacyVersioning {
+		t.Errorf("DeleteOldVersions: %v", err)
+	}
+
+	// the current version is untouched
+	if err := s.DownloadObject("/c/o", dest); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(dest); string(b) != "content" {
+		t.Errorf("object content %q after legacy version calls", b)
+	}
+}
@@ -2,6 +2,8 @@ package gopenstack
 
 import (
 	"encoding/json"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -13,7 +15,8 @@ type DateTime struct {
 
 func (dt *DateTime) UnmarshalJSON(data []byte) error {
 	var s string
-	if err := json.Unmarshal(data, &s); err != nil {
+	if err := json.Unmarshal(data, &s); err != nil || s == "" {
+		// null or empty date is a zero time
 		return err
 	}
 	//2014-09-16T06:50:09+02:00 RFC3339
@@ -26,7 +29,10 @@ func (dt *DateTime) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DateTimeOsFormat is the layout of dates returned (and expected) by Openstack
 // 2014-11-01T17:36:54.213280
+const DateTimeOsFormat = "2006-01-02T15:04:05.000000"
+
 // // DateTime represents date as returned by Openstack
 type DateTimeOs struct {
 	time.Time
@@ -34,13 +40,52 @@ type DateTimeOs struct {
 
 func (dt *DateTimeOs) UnmarshalJSON(data []byte) error {
 	var s string
-	if err := json.Unmarshal(data, &s); err != nil {
+	if err := json.Unmarshal(data, &s); err != nil || s == "" {
 		return err
 	}
-	t, err := time.Parse("2006-01-02T15:04:05.000000", s)
+	t, err := time.Parse(DateTimeOsFormat, s)
 	if err != nil {
 		return err
 	}
 	dt.Time = t
 	return nil
 }
+
+// FormatDateTimeOs returns t (as UTC) in the Openstack format
+func FormatDateTimeOs(t time.Time) string {
+	return t.UTC().Format(DateTimeOsFormat)
+}
+
+// Link represents a link of an openstack resource (self, bookmark, next...)
+type Link struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+// PageQuery adds limit (if > 0) and marker (if set) to v and
+// returns the corresponding query string (empty if there is no parameter)
+func PageQuery(v url.Values, limit int, marker string) string {
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if marker != "" {
+		v.Set("marker", marker)
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// NextMarker returns the marker of the "next" link in links, if any
+func NextMarker(links []Link) string {
+	for _, l := range links {
+		if l.Rel != "next" {
+			continue
+		}
+		if u, err := url.Parse(l.Href); err == nil {
+			return u.Query().Get("marker")
+		}
+	}
+	return ""
+}
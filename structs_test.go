@@ -0,0 +1,15 @@
+package gopenstack
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestPageQuery(t *testing.T) {
+	if q := PageQuery(url.Values{}, 0, ""); q != "" {
+		t.Errorf("empty query: %q", q)
+	}
+	if q := PageQuery(url.Values{"name": {"a b"}}, 10, "m"); q != "?limit=10&marker=m&name=a+b" {
+		t.Errorf("query: %q", q)
+	}
+}
@@ -0,0 +1,98 @@
+package gopenstack
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Waiting for asynchronous resources
+// Resources (servers, volumes, images...) are polled with an exponential
+// backoff until they reach a target status, a failure status or the
+// context is done.
+
+// Default polling intervals
+const (
+	defaultWaitInterval    = 2 * time.Second
+	defaultWaitMaxInterval = 30 * time.Second
+)
+
+// StatusFunc returns the current status of a resource
+type StatusFunc func() (status string, err error)
+
+// WaitOptions represents options of WaitForStatus
+// Statuses are compared case insensitively
+type WaitOptions struct {
+	Target      []string      // Statuses to reach
+	Failure     []string      // Statuses failing the wait, "ERROR" if nil
+	Interval    time.Duration // First polling interval (doubled at each poll), 2s if zero
+	MaxInterval time.Duration // Max polling interval, 30s if zero
+
+	// NotFoundIsTarget makes a 404 returned by the StatusFunc reach
+	// the target (eg waiting for a deletion)
+	NotFoundIsTarget bool
+}
+
+// StatusNotFound is the status returned by WaitForStatus when
+// the resource was not found and options.NotFoundIsTarget is set
+const StatusNotFound = "NOT_FOUND"
+
+// WaitForStatus polls get until it returns a status of options.Target.
+// It returns the last status and a *StatusError if a failure status
+// is reached or ctx.Err() if ctx is done before.
+func WaitForStatus(ctx context.Context, get StatusFunc, options *WaitOptions) (status string, err error) {
+	failure := options.Failure
+	if failure == nil {
+		failure = []string{"ERROR"}
+	}
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	maxInterval := options.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxInterval
+	}
+
+	for {
+		status, err = get()
+		var httpErr *HttpError
+		if err != nil && options.NotFoundIsTarget && errors.As(err, &httpErr) && httpErr.StatusCode == 404 {
+			return StatusNotFound, nil
+		}
+		if err != nil {
+			return
+		}
+		if statusIn(status, options.Target) {
+			return status, nil
+		}
+		if statusIn(status, failure) {
+			return status, &StatusError{Status: status}
+		}
+
+		// wait with jitter (+/- 10%)
+		delay := interval + time.Duration((rand.Float64()-0.5)*0.2*float64(interval))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// statusIn returns true if status is in statuses
+func statusIn(status string, statuses []string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(status, s) {
+			return true
+		}
+	}
+	return false
+}
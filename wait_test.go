@@ -0,0 +1,64 @@
+package gopenstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	notFound := &HttpError{StatusCode: 404, Status: "Not Found"}
+	for _, tc := range []struct {
+		name     string
+		statuses []string // successive statuses, "404" for a not found error
+		notFound bool
+		status   string
+		failed   bool // *StatusError expected
+		err      error
+	}{
+		{"target reached", []string{"BUILD", "build", "ACTIVE"}, false, "ACTIVE", false, nil},
+		{"failure status", []string{"BUILD", "ERROR"}, false, "ERROR", true, nil},
+		{"not found is target", []string{"DELETING", "404"}, true, StatusNotFound, false, nil},
+		{"not found", []string{"DELETING", "404"}, false, "", false, notFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			status, err := WaitForStatus(context.Background(), func() (string, error) {
+				s := tc.statuses[polls]
+				polls++
+				if s == "404" {
+					return "", notFound
+				}
+				return s, nil
+			}, &WaitOptions{Target: []string{"ACTIVE"}, Interval: time.Millisecond, NotFoundIsTarget: tc.notFound})
+
+			if polls != len(tc.statuses) || status != tc.status {
+				t.Errorf("%d polls, status %q", polls, status)
+			}
+			var statusErr *StatusError
+			switch {
+			case tc.failed:
+				if !errors.As(err, &statusErr) || statusErr.Status != tc.status {
+					t.Errorf("expected a status error: %v", err)
+				}
+			case err != tc.err:
+				t.Errorf("error %v, expected %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWaitForStatusCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	status, err := WaitForStatus(ctx, func() (string, error) {
+		if polls++; polls == 3 {
+			cancel()
+		}
+		return "BUILD", nil
+	}, &WaitOptions{Target: []string{"ACTIVE"}, Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond})
+	if err != context.Canceled || status != "BUILD" || polls != 3 {
+		t.Errorf("canceled wait: %d polls, %q, %v", polls, status, err)
+	}
+}